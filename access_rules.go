@@ -0,0 +1,102 @@
+package ovh
+
+import (
+	"net/http"
+	"strings"
+)
+
+// accessRuleMethods lists the methods granted by AccessRuleBuilder.AllowAll
+var accessRuleMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// AccessRuleBuilder helps build the access rules of a consumer key request
+//
+// e.g. :
+// 		rules := ovh.NewAccessRuleBuilder().
+// 			AllowGet("/me").
+// 			AllowAll("/domain/zone/*").
+// 			Build()
+// 		state, err := client.RequestConsumerKey(rules)
+type AccessRuleBuilder struct {
+	rules []AccessRule
+}
+
+// NewAccessRuleBuilder returns an empty AccessRuleBuilder
+func NewAccessRuleBuilder() *AccessRuleBuilder {
+	return &AccessRuleBuilder{}
+}
+
+// Allow grants ``method`` on ``path``. Duplicated rules are ignored.
+func (b *AccessRuleBuilder) Allow(method, path string) *AccessRuleBuilder {
+	for _, rule := range b.rules {
+		if rule.Method == method && rule.Path == path {
+			return b
+		}
+	}
+	b.rules = append(b.rules, AccessRule{Method: method, Path: path})
+	return b
+}
+
+// AllowGet grants GET on ``path``
+func (b *AccessRuleBuilder) AllowGet(path string) *AccessRuleBuilder {
+	return b.Allow(http.MethodGet, path)
+}
+
+// AllowPost grants POST on ``path``
+func (b *AccessRuleBuilder) AllowPost(path string) *AccessRuleBuilder {
+	return b.Allow(http.MethodPost, path)
+}
+
+// AllowPut grants PUT on ``path``
+func (b *AccessRuleBuilder) AllowPut(path string) *AccessRuleBuilder {
+	return b.Allow(http.MethodPut, path)
+}
+
+// AllowDelete grants DELETE on ``path``
+func (b *AccessRuleBuilder) AllowDelete(path string) *AccessRuleBuilder {
+	return b.Allow(http.MethodDelete, path)
+}
+
+// AllowAll grants GET, POST, PUT and DELETE on ``path``
+func (b *AccessRuleBuilder) AllowAll(path string) *AccessRuleBuilder {
+	for _, method := range accessRuleMethods {
+		b.Allow(method, path)
+	}
+	return b
+}
+
+// Build returns the rules, ready to be passed to RequestConsumerKey
+func (b *AccessRuleBuilder) Build() []AccessRule {
+	rules := make([]AccessRule, len(b.rules))
+	copy(rules, b.rules)
+	return rules
+}
+
+// AllowPrefix grants ``methods`` on ``prefix`` and the whole subtree below it,
+// all methods when none is given
+func (b *AccessRuleBuilder) AllowPrefix(prefix string, methods ...string) *AccessRuleBuilder {
+	if len(methods) == 0 {
+		methods = accessRuleMethods
+	}
+
+	root := strings.TrimSuffix(strings.TrimSuffix(prefix, "*"), "/")
+	for _, method := range methods {
+		if root != "" {
+			b.Allow(method, root)
+		}
+		b.Allow(method, root+"/*")
+	}
+	return b
+}
+
+// AccessRulesForPrefix returns the access rules granting ``methods`` on
+// ``prefix`` and the whole subtree below it, all methods when none is given.
+// Both "/dedicated/server" and "/dedicated/server/*" grant the server list as
+// well as every server.
+func AccessRulesForPrefix(prefix string, methods ...string) []AccessRule {
+	return NewAccessRuleBuilder().AllowPrefix(prefix, methods...).Build()
+}
@@ -0,0 +1,99 @@
+package ovh
+
+import (
+	"strings"
+	"time"
+)
+
+// Credential represents the consumer key the client is authenticated with, as
+// returned by /auth/currentCredential
+type Credential struct {
+	CredentialID  int64 `json:"credentialId"`
+	ApplicationID int64 `json:"applicationId"`
+	// Access rules granted to the consumer key
+	Rules []AccessRule `json:"rules"`
+	// One of "pendingValidation", "validated", "expired" or "refused"
+	Status string `json:"status"`
+	// IPs the consumer key is restricted to, if any
+	AllowedIPs []string  `json:"allowedIPs"`
+	OvhSupport bool      `json:"ovhSupport"`
+	Creation   time.Time `json:"creation"`
+	Expiration time.Time `json:"expiration"`
+	LastUse    time.Time `json:"lastUse"`
+}
+
+// HasAccess reports whether the access rules of the credential allow calling
+// ``method`` on ``path``, as the API would, without issuing the call. A "*"
+// in a rule path matches any sequence of characters, "/" included.
+func (cred *Credential) HasAccess(method, path string) bool {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	for _, rule := range cred.Rules {
+		if strings.EqualFold(rule.Method, method) && matchRulePath(rule.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRulePath reports whether ``path`` matches the access rule ``pattern``
+func matchRulePath(pattern, path string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == path
+	}
+
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	path = path[len(parts[0]):]
+
+	// Match the middle parts as early as possible, leaving the most room
+	// for the last one, anchored at the end
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(path, part)
+		if i < 0 {
+			return false
+		}
+		path = path[i+len(part):]
+	}
+	return len(path) >= len(last) && strings.HasSuffix(path, last)
+}
+
+// Ping checks the API is reachable by fetching the unauthenticated /auth/time
+func (c *Client) Ping() error {
+	_, err := c.AuthTime()
+	return err
+}
+
+// CheckCredentials checks the client credentials are accepted by the API by
+// fetching /auth/currentCredential
+func (c *Client) CheckCredentials() error {
+	_, err := c.CurrentCredential()
+	return err
+}
+
+// CurrentCredential returns the details of the consumer key the client is
+// authenticated with, including its access rules. It helps diagnose requests
+// rejected because of an insufficiently scoped consumer key.
+func (c *Client) CurrentCredential() (*Credential, error) {
+	credential := &Credential{}
+	if err := c.GetInto("/auth/currentCredential", credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// Logout revokes the consumer key the client is authenticated with, through
+// /auth/logout. On success, the client forgets the key: authenticated requests
+// fail with ErrMissingConsumerKey until a new one is requested.
+func (c *Client) Logout() error {
+	if err := c.PostInto("/auth/logout", nil, nil); err != nil {
+		return err
+	}
+
+	c.setConsumerKey("")
+	return nil
+}
@@ -0,0 +1,38 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// BatchResult represents a single item of a batch response
+type BatchResult struct {
+	// Batched item, as found in the request path
+	Key string `json:"key"`
+	// Item as would have been returned by a single request
+	Value json.RawMessage `json:"value"`
+	// Error message, if this item could not be fetched
+	Error string `json:"error"`
+}
+
+// Batch Issues an authenticated batch get request on /path, where the last
+// path segment lists multiple items joined by ``separator``. For instance:
+//
+//	client.Batch("/domain/zone/example.com/record/1,2,3", ",")
+func (c *Client) Batch(path, separator string) ([]BatchResult, error) {
+	opts := &callOptions{
+		headers: http.Header{"X-Ovh-Batch": []string{separator}},
+	}
+
+	response, err := c.callWithOptions(context.Background(), "GET", path, nil, true, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []BatchResult{}
+	if err := response.UnmarshalInto(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,45 @@
+package ovh
+
+import (
+	"context"
+	"sync"
+)
+
+// Map runs ``fn`` on each of ``items`` with at most ``concurrency`` calls in
+// parallel, e.g. to delete many DNS records. The returned errors match
+// ``items`` by index, nil for the successful ones. Once ``ctx`` is done, the
+// items not started yet are skipped and get ``ctx.Err()``.
+func Map(ctx context.Context, concurrency int, items []string, fn func(ctx context.Context, item string) error) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(ctx, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,69 @@
+package ovh
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetCached is like Get, but keeps the last response of ``path`` along with
+// its ETag or Last-Modified header. Subsequent calls make the request
+// conditional, and answer with the cached response when the API replies 304
+// Not Modified, in which case NotModified is set.
+func (c *Client) GetCached(path string) (*APIResponse, error) {
+	c.lock.RLock()
+	cached := c.cache[path]
+	c.lock.RUnlock()
+
+	var opts []CallOption
+	if cached != nil {
+		opts = append(opts, conditional(cached))
+	}
+
+	response, err := c.CallWithOptions(context.Background(), "GET", path, nil, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && cached != nil:
+		hit := *cached
+		hit.NotModified = true
+		hit.QueryID = response.QueryID
+		hit.RateLimit = response.RateLimit
+		hit.Attempts = response.Attempts
+		return &hit, nil
+	case response.StatusCode == http.StatusOK:
+		c.lock.Lock()
+		if response.ETag != "" || response.Header.Get("Last-Modified") != "" {
+			if c.cache == nil {
+				c.cache = map[string]*APIResponse{}
+			}
+			c.cache[path] = response
+		} else {
+			// The cached response is outdated, and can no longer be validated
+			delete(c.cache, path)
+		}
+		c.lock.Unlock()
+	}
+	return response, nil
+}
+
+// conditional makes the call conditional on the resource having changed
+// since ``cached``
+func conditional(cached *APIResponse) CallOption {
+	return func(o *callOptions) {
+		if cached.ETag != "" {
+			o.setHeader("If-None-Match", cached.ETag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			o.setHeader("If-Modified-Since", lastModified)
+		}
+	}
+}
+
+// ClearCache drops the responses kept by GetCached
+func (c *Client) ClearCache() {
+	c.lock.Lock()
+	c.cache = nil
+	c.lock.Unlock()
+}
@@ -0,0 +1,96 @@
+package ovh
+
+import (
+	"net/http"
+	"testing"
+)
+
+// cacheHandler answers the queued responses in order. An empty body answers
+// 304 Not Modified.
+type cacheHandler struct {
+	responses []cachedReply
+	// Conditional headers of each request
+	ifNoneMatch []string
+}
+
+type cachedReply struct {
+	etag string
+	body string
+}
+
+func (h *cacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.ifNoneMatch = append(h.ifNoneMatch, r.Header.Get("If-None-Match"))
+	reply := h.responses[0]
+	h.responses = h.responses[1:]
+
+	if reply.body == "" {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if reply.etag != "" {
+		w.Header().Set("ETag", reply.etag)
+	}
+	w.Write([]byte(reply.body))
+}
+
+func TestGetCached(t *testing.T) {
+	handler := &cacheHandler{responses: []cachedReply{
+		{etag: `"v1"`, body: `{"v":1}`},
+		{},
+	}}
+	client, _ := newTestClient(t, handler.ServeHTTP)
+
+	first, err := client.GetCached("/me")
+	if err != nil {
+		t.Fatalf("GetCached: %v", err)
+	}
+	if first.NotModified || string(first.Body) != `{"v":1}` {
+		t.Errorf("first response: NotModified %v, body %s", first.NotModified, first.Body)
+	}
+
+	second, err := client.GetCached("/me")
+	if err != nil {
+		t.Fatalf("GetCached: %v", err)
+	}
+	if !second.NotModified || second.StatusCode != http.StatusOK || string(second.Body) != `{"v":1}` {
+		t.Errorf("304 response: NotModified %v, status %d, body %s", second.NotModified, second.StatusCode, second.Body)
+	}
+	if handler.ifNoneMatch[1] != `"v1"` {
+		t.Errorf("If-None-Match = %q, want \"v1\"", handler.ifNoneMatch[1])
+	}
+}
+
+// A response without validators replaces the cached one, which is dropped
+func TestGetCachedWithoutValidators(t *testing.T) {
+	handler := &cacheHandler{responses: []cachedReply{
+		{etag: `"v1"`, body: `{"v":1}`},
+		{body: `{"v":2}`},
+		// An intermediary replying 304 anyway
+		{},
+	}}
+	client, _ := newTestClient(t, handler.ServeHTTP)
+
+	for _, want := range []string{`{"v":1}`, `{"v":2}`} {
+		response, err := client.GetCached("/me")
+		if err != nil {
+			t.Fatalf("GetCached: %v", err)
+		}
+		if string(response.Body) != want {
+			t.Errorf("body = %s, want %s", response.Body, want)
+		}
+	}
+
+	third, err := client.GetCached("/me")
+	if err != nil {
+		t.Fatalf("GetCached: %v", err)
+	}
+	if third.NotModified || string(third.Body) == `{"v":1}` {
+		t.Errorf("GetCached answered with the replaced response %s", third.Body)
+	}
+	if third.StatusCode != http.StatusNotModified {
+		t.Errorf("status = %d, want the uncached 304", third.StatusCode)
+	}
+	if handler.ifNoneMatch[2] != "" {
+		t.Errorf("If-None-Match = %q, want none", handler.ifNoneMatch[2])
+	}
+}
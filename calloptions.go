@@ -0,0 +1,161 @@
+package ovh
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// callOptions holds the per call tweaks of the request
+type callOptions struct {
+	// Extra request headers
+	headers http.Header
+	// Do not send the Accept header on authenticated requests
+	noAccept bool
+	// Do not send the Content-Type header along with the body
+	noContentType bool
+	// Streamed request body, hashed in place to sign the request, with its
+	// offset and length
+	stream      io.ReadSeeker
+	streamStart int64
+	streamSize  int64
+	// Consumer key signing the request instead of the client one
+	consumerKey string
+	// Content-Type of the request body instead of the client one
+	contentType string
+}
+
+// CallOption tweaks a single call made with CallWithOptions
+type CallOption func(*callOptions)
+
+// NoAcceptHeader omits the Accept header otherwise sent with authenticated
+// requests, to work around endpoints which choke on it
+func NoAcceptHeader() CallOption {
+	return func(o *callOptions) {
+		o.noAccept = true
+	}
+}
+
+// NoContentTypeHeader omits the Content-Type header otherwise sent along with
+// the request body, to work around endpoints which choke on it
+func NoContentTypeHeader() CallOption {
+	return func(o *callOptions) {
+		o.noContentType = true
+	}
+}
+
+// IfMatch makes the call conditional on the resource still matching ``etag``,
+// as found in APIResponse.ETag, for safe read-modify-write cycles
+func IfMatch(etag string) CallOption {
+	return func(o *callOptions) {
+		o.setHeader("If-Match", etag)
+	}
+}
+
+// ContentType sends the request body with the ``contentType`` Content-Type,
+// e.g. "application/json" for endpoints rejecting the charset parameter
+func ContentType(contentType string) CallOption {
+	return func(o *callOptions) {
+		o.contentType = contentType
+	}
+}
+
+// AsConsumer signs the call with ``consumerKey`` rather than the consumer key
+// of the client, so that a single client serves several tenants. It has no
+// effect on clients authenticated with OAuth2.
+func AsConsumer(consumerKey string) CallOption {
+	return func(o *callOptions) {
+		o.consumerKey = consumerKey
+	}
+}
+
+// idempotencyKeyHeader carries the key set with IdempotencyKey
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKey attaches ``key`` to the call, so that the API processes it
+// only once however many times it is sent. It makes retrying a POST safe, so
+// such calls are retried as configured in Client.Retry even without
+// RetryNonIdempotent. Use a new unique key, e.g. a UUID, for each operation.
+func IdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.setHeader(idempotencyKeyHeader, key)
+	}
+}
+
+// protectedHeaders lists the headers set by the client, which Headers cannot
+// override. Accept-Encoding must match what the client can decompress.
+var protectedHeaders = []string{
+	"Accept-Encoding",
+	"Authorization",
+	"X-Ovh-Application",
+	"X-Ovh-Consumer",
+	"X-Ovh-Signature",
+	"X-Ovh-Timestamp",
+}
+
+// Headers adds ``headers`` to the request, e.g. for tracing. They take
+// precedence over the client ones, such as User-Agent, Accept or Content-Type,
+// except for the authentication headers and Accept-Encoding, which are always
+// the ones computed by the client.
+func Headers(headers http.Header) CallOption {
+	return func(o *callOptions) {
+		for name, values := range headers {
+			for _, value := range values {
+				o.addHeader(name, value)
+			}
+		}
+		for _, name := range protectedHeaders {
+			o.headers.Del(name)
+		}
+	}
+}
+
+// addHeader adds an extra request header
+func (o *callOptions) addHeader(name, value string) {
+	if o.headers == nil {
+		o.headers = http.Header{}
+	}
+	o.headers.Add(name, value)
+}
+
+// setHeader sets an extra request header
+func (o *callOptions) setHeader(name, value string) {
+	if o.headers == nil {
+		o.headers = http.Header{}
+	}
+	o.headers.Set(name, value)
+}
+
+// CallWithOptions is like CallWithContext, tweaked by ``opts``
+func (c *Client) CallWithOptions(ctx context.Context, method, path string, data interface{}, needAuth bool, opts ...CallOption) (*APIResponse, error) {
+	options := &callOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return c.callWithOptions(ctx, method, path, data, needAuth, options)
+}
+
+// PutIfMatch Issues an authenticated put request on /path, only applied if the
+// resource still matches ``etag``. Otherwise the API answers with 412
+// Precondition Failed.
+func (c *Client) PutIfMatch(path string, data interface{}, etag string) (*APIResponse, error) {
+	return c.CallWithOptions(context.Background(), "PUT", path, data, true, IfMatch(etag))
+}
+
+// PostWithOptions Issues an authenticated post request on /path, tweaked by
+// ``opts``. e.g. :
+// 		client.PostWithOptions("/me/task", data, ovh.IdempotencyKey(key))
+func (c *Client) PostWithOptions(path string, data interface{}, opts ...CallOption) (*APIResponse, error) {
+	return c.CallWithOptions(context.Background(), "POST", path, data, true, opts...)
+}
+
+// CallAs is like Call, but the request is signed with ``consumerKey`` rather
+// than the consumer key of the client. See AsConsumer.
+func (c *Client) CallAs(consumerKey, method, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithOptions(context.Background(), method, path, data, true, AsConsumer(consumerKey))
+}
+
+// CallWithHeaders is like Call, with the extra ``headers``. See Headers.
+func (c *Client) CallWithHeaders(method, path string, data interface{}, needAuth bool, headers http.Header) (*APIResponse, error) {
+	return c.CallWithOptions(context.Background(), method, path, data, needAuth, Headers(headers))
+}
@@ -0,0 +1,56 @@
+package ovh
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCallWithHeaders(t *testing.T) {
+	client, recorded := newRecordingClient(t, WithUserAgent("client-agent/1.0"))
+
+	headers := http.Header{
+		"X-Request-Id":    {"trace-1"},
+		"User-Agent":      {"caller-agent/2.0"},
+		"Accept":          {"text/plain"},
+		"Accept-Encoding": {"br"},
+		// Authentication headers cannot be overridden
+		"Authorization":     {"Bearer forged"},
+		"X-Ovh-Application": {"forged"},
+		"X-Ovh-Consumer":    {"forged"},
+		"X-Ovh-Signature":   {"forged"},
+		"X-Ovh-Timestamp":   {"0"},
+	}
+	if _, err := client.CallWithHeaders("GET", "/me", nil, true, headers); err != nil {
+		t.Fatalf("CallWithHeaders: %v", err)
+	}
+
+	want := map[string]string{
+		"X-Request-Id":      "trace-1",
+		"User-Agent":        "caller-agent/2.0",
+		"Accept":            "text/plain",
+		"Accept-Encoding":   "gzip, deflate",
+		"Authorization":     "",
+		"X-Ovh-Application": testApplicationKey,
+		"X-Ovh-Consumer":    testConsumerKey,
+	}
+	for name, value := range want {
+		if got := recorded.Header.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if got := recorded.Header.Values("X-Ovh-Signature"); len(got) != 1 {
+		t.Errorf("X-Ovh-Signature = %q, want the computed one only", got)
+	}
+	checkSignature(t, client, recorded)
+
+	// Without caller headers, the client ones apply
+	if _, err := client.CallWithHeaders("GET", "/me", nil, true, nil); err != nil {
+		t.Fatalf("CallWithHeaders: %v", err)
+	}
+	if got := recorded.Header.Get("User-Agent"); got != "client-agent/1.0" {
+		t.Errorf("User-Agent = %q, want client-agent/1.0", got)
+	}
+	if got := recorded.Header.Get("Accept"); got != "application/json" {
+		t.Errorf("Accept = %q, want application/json", got)
+	}
+}
@@ -0,0 +1,38 @@
+package ovh
+
+import (
+	"bytes"
+	"mime"
+	"strings"
+)
+
+// toUTF8 converts a response body to UTF-8, according to the charset of its
+// ``contentType``. UTF-8, US-ASCII and ISO-8859-1 are supported. Bodies in
+// other charsets, or without any, are returned untouched, like bodies of non
+// JSON content types, which UnmarshalInto then fails to decode.
+func toUTF8(contentType string, body []byte) []byte {
+	if contentType == "" {
+		return body
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return body
+	}
+
+	switch strings.ToLower(params["charset"]) {
+	case "iso-8859-1", "latin1", "latin-1":
+		return latin1ToUTF8(body)
+	}
+	return body
+}
+
+// latin1ToUTF8 converts an ISO-8859-1 encoded ``body`` to UTF-8, each byte
+// being the code point of a character
+func latin1ToUTF8(body []byte) []byte {
+	var converted bytes.Buffer
+	converted.Grow(len(body))
+	for _, b := range body {
+		converted.WriteRune(rune(b))
+	}
+	return converted.Bytes()
+}
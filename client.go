@@ -2,28 +2,83 @@ package ovh
 
 import (
 	"bytes"
-	"crypto/sha1"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/user"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"gopkg.in/ini.v1"
+	"unicode/utf8"
 )
 
 // DefaultTimeout api requests after 180s
 const DefaultTimeout = 180
 
+// DefaultMaxResponseBytes bounds the size of buffered responses, see
+// WithMaxResponseBytes
+const DefaultMaxResponseBytes = 64 << 20
+
+// Version of this client library
+const Version = "0.1.0"
+
+// DefaultContentType is the type of request bodies, unless overridden with
+// WithContentType or the ContentType call option
+const DefaultContentType = "application/json;charset=utf-8"
+
+// DefaultUserAgent is sent with each request, unless overridden with WithUserAgent
+const DefaultUserAgent = "go-ovh/" + Version
+
 // Custom errors
 var (
-	ErrNoEnpoint = errors.New("ovh: no endpoint provided")
+	ErrNoEnpoint                = errors.New("ovh: no endpoint provided")
+	ErrEndpointExists           = errors.New("ovh: endpoint already registered")
+	ErrMissingApplicationKey    = errors.New("ovh: missing application key for authenticated request")
+	ErrMissingApplicationSecret = errors.New("ovh: missing application secret for authenticated request")
+	ErrMissingConsumerKey       = errors.New("ovh: missing consumer key for authenticated request")
+	ErrResponseTooLarge         = errors.New("ovh: response body too large")
+
+	// Errors matching API failures with errors.Is, based on their HTTP status
+	ErrUnauthorized = errors.New("ovh: unauthorized")
+	ErrForbidden    = errors.New("ovh: forbidden")
+	ErrNotFound     = errors.New("ovh: not found")
+	ErrRateLimited  = errors.New("ovh: rate limited")
 )
 
+// statusErrors maps HTTP status codes to the matching sentinel error
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:    ErrUnauthorized,
+	http.StatusForbidden:       ErrForbidden,
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
+// Environment variables taking precedence over the configuration files.
+// Values explicitly given to the constructors take precedence over both.
+const (
+	EnvEndpoint          = "OVH_ENDPOINT"
+	EnvApplicationKey    = "OVH_APPLICATION_KEY"
+	EnvApplicationSecret = "OVH_APPLICATION_SECRET"
+	EnvConsumerKey       = "OVH_CONSUMER_KEY"
+)
+
+// ConfigPaths lists the configuration files loaded by default, by order of
+// increasing priority. A leading "~/" stands for the current user home.
+var ConfigPaths = []string{
+	"/etc/ovh.conf",
+	"~/.ovh.conf",
+	"./ovh.conf",
+}
+
 // Endpoint reprensents an API endpoint
 type Endpoint string
 
@@ -31,6 +86,7 @@ type Endpoint string
 const (
 	OvhEU        Endpoint = "https://eu.api.ovh.com/1.0"
 	OvhCA                 = "https://ca.api.ovh.com/1.0"
+	OvhUS                 = "https://api.us.ovhcloud.com/1.0"
 	KimsufiEU             = "https://eu.api.kimsufi.com/1.0"
 	KimsufiCA             = "https://ca.api.kimsufi.com/1.0"
 	SoyoustartEU          = "https://eu.api.soyoustart.com/1.0"
@@ -42,6 +98,7 @@ const (
 var Endpoints = map[string]Endpoint{
 	"ovh-eu":        OvhEU,
 	"ovh-ca":        OvhCA,
+	"ovh-us":        OvhUS,
 	"kimsufi-eu":    KimsufiEU,
 	"kimsufi-ca":    KimsufiCA,
 	"soyoustart-eu": SoyoustartEU,
@@ -49,20 +106,174 @@ var Endpoints = map[string]Endpoint{
 	"runabove-ca":   RunaboveCA,
 }
 
-// Client represents an an OVH API client
+// EndpointAliases maps alternative endpoint names, such as the current
+// OVHcloud ones, to the name of the endpoint in Endpoints they stand for
+var EndpointAliases = map[string]string{
+	"ovhcloud-eu": "ovh-eu",
+	"ovhcloud-ca": "ovh-ca",
+	"ovhcloud-us": "ovh-us",
+}
+
+// endpointsLock guards Endpoints against concurrent RegisterEndpoint calls
+var endpointsLock sync.RWMutex
+
+// RegisterEndpoint makes ``uri`` available under ``name``, for instance a
+// sandbox or private deployment. ``uri`` must be an absolute http(s) URL.
+// Existing names, including the built-in ones, are only replaced when
+// ``override`` is true.
+func RegisterEndpoint(name, uri string, override bool) error {
+	if name == "" || uri == "" {
+		return errors.New("ovh: endpoint name and uri are required")
+	}
+	endpoint, err := parseEndpointURL(uri)
+	if err != nil {
+		return err
+	}
+
+	endpointsLock.Lock()
+	defer endpointsLock.Unlock()
+
+	if _, ok := Endpoints[name]; ok && !override {
+		return ErrEndpointExists
+	}
+	Endpoints[name] = endpoint
+	return nil
+}
+
+// SupportedEndpoints returns the sorted names of all registered endpoints,
+// along with the aliases of EndpointAliases standing for one of them: the
+// names IsValidEndpoint accepts
+func SupportedEndpoints() []string {
+	endpointsLock.RLock()
+	defer endpointsLock.RUnlock()
+
+	names := make([]string, 0, len(Endpoints)+len(EndpointAliases))
+	for name := range Endpoints {
+		names = append(names, name)
+	}
+	for alias, name := range EndpointAliases {
+		_, registered := Endpoints[alias]
+		if _, ok := Endpoints[name]; ok && !registered {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsValidEndpoint reports whether ``name`` is a registered endpoint name, or an
+// alias of one
+func IsValidEndpoint(name string) bool {
+	_, ok := lookupEndpoint(name)
+	return ok
+}
+
+// parseEndpointURL validates ``rawURL`` is an absolute http(s) URL
+func parseEndpointURL(rawURL string) (Endpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("ovh: invalid endpoint URL %q: %v", rawURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("ovh: invalid endpoint URL %q: not an absolute http(s) URL", rawURL)
+	}
+	return Endpoint(strings.TrimSuffix(rawURL, "/")), nil
+}
+
+// CanonicalEndpointName returns the name ``name`` is an alias of, see
+// EndpointAliases, or ``name`` itself. A name registered in Endpoints is never
+// an alias. Configuration sections are looked up by canonical name.
+func CanonicalEndpointName(name string) string {
+	endpointsLock.RLock()
+	defer endpointsLock.RUnlock()
+
+	return canonicalEndpointName(name)
+}
+
+// canonicalEndpointName is CanonicalEndpointName, endpointsLock being held
+func canonicalEndpointName(name string) string {
+	if _, ok := Endpoints[name]; ok {
+		return name
+	}
+	if primary, ok := EndpointAliases[name]; ok {
+		return primary
+	}
+	return name
+}
+
+// lookupEndpoint returns the endpoint registered under ``name``, or under
+// the name it is an alias of
+func lookupEndpoint(name string) (Endpoint, bool) {
+	endpointsLock.RLock()
+	defer endpointsLock.RUnlock()
+
+	endpoint, ok := Endpoints[canonicalEndpointName(name)]
+	return endpoint, ok
+}
+
+// Client represents an an OVH API client. It is safe for concurrent use by
+// multiple goroutines, as long as its exported fields are not modified while
+// requests are in flight.
 type Client struct {
 	endpoint          Endpoint
 	applicationKey    string
 	applicationSecret string
 	consumerKey       string
-	Timeout           time.Duration
-	timeDelta         int64
-	client            *http.Client
+	// Timeout of each API request attempt, 0 for none. It is combined with
+	// the deadline of the call context, if any: whichever expires first
+	// aborts the request. Set it at construction time with WithTimeout and
+	// treat it as read-only afterwards, modifying it races with requests in
+	// flight.
+	Timeout time.Duration
+	Retry   RetryConfig
+	client  *http.Client
+
+	signatureAlgorithm SignatureAlgorithm
+	oauth2             *oauth2Config
+	userAgent          string
+	rateLimiter        *rateLimiter
+	observer           Observer
+	disableCompression bool
+	forceClockSync     bool
+	disableClockSync   bool
+	maxResponseBytes   int64
+	dryRun             bool
+	contentType        string
+	now                func() time.Time
+
+	// Construction time settings, see options.go
+	endpointName string
+	baseURL      Endpoint
+	configFiles  []string
+	profile      string
+	proxyURL     *url.URL
+
+	credentialSource CredentialSource
+
+	dialTimeout         time.Duration
+	tlsHandshakeTimeout time.Duration
+	apiVersion          string
+	oauth2TokenURL      string
+	// Transport created by the package, if any, as opposed to one given
+	// with WithHTTPClient
+	transport *http.Transport
+
+	// lock guards the fields below, and consumerKey, which may change
+	// while requests are in flight
+	lock sync.RWMutex
 
 	// sync.Once would consider init done, even in case of error
-	// running it multiple times/races are not issue. Hence a good
+	// running it multiple times is not an issue. Hence a good
 	// old flag
+	timeDelta     int64
 	timeDeltaDone bool
+
+	debugFunc DebugFunc
+
+	// Responses of GetCached, by path, see cache.go
+	cache map[string]*APIResponse
+	// Schemas of the services, see schema.go
+	schemas map[string]*Schema
 }
 
 // APIResponse represents a response from OVH API
@@ -70,18 +281,120 @@ type APIResponse struct {
 	StatusCode int
 	Status     string
 	Body       []byte
+
+	// Value of the X-Ovh-Queryid response header. Include it in support tickets
+	QueryID string
+	// Value of the ETag response header, if any. See IfMatch
+	ETag string
+	// Quota headers of the response, nil if there is none
+	RateLimit *RateLimitStatus
+	Header    http.Header
+	// Whether the API answered 304 Not Modified to GetCached, Body being the
+	// cached one
+	NotModified bool
+	// Number of requests sent to get the response, more than 1 when it was
+	// retried, see Client.Retry
+	Attempts int
+
+	// Underlying response, its body replaced by a reader over Body
+	response *http.Response
+	// Method and path of the call, to identify it in errors
+	method string
+	path   string
+}
+
+// Reader returns a reader over the buffered body, e.g. to hand it to a non
+// JSON decoder
+func (r *APIResponse) Reader() io.Reader {
+	return bytes.NewReader(r.Body)
 }
 
-// APIError represents an unmarshalled reponse from OVH in case of error
+// HTTPResponse returns the underlying *http.Response, for inspection of what
+// APIResponse does not expose, like the protocol version or TLS state. Its
+// body is already buffered and can be read again.
+func (r *APIResponse) HTTPResponse() *http.Response {
+	return r.response
+}
+
+// APIError represents an unmarshalled reponse from OVH in case of error.
+//
+// ErrorCode and HTTPCode used to be ints, which could never be decoded from
+// the strings OVH returns. Code comparing ErrorCode to an HTTP status must
+// use StatusCode instead.
 type APIError struct {
-	ErrorCode int    `json:"errorCode"`
-	HTTPCode  int    `json:"httpCode"`
-	Message   string `json:"message"`
+	// OVH error code, when provided. e.g. "QUERY_TIME_OUT"
+	ErrorCode string `json:"errorCode"`
+	// HTTP status as reported in the error body, when provided. e.g.
+	// "404 Not Found"
+	HTTPCode string `json:"httpCode"`
+	Message  string `json:"message"`
+	// Error class, when provided. e.g. "Client::NotFound"
+	Class string `json:"class,omitempty"`
+	// Additional diagnostic information, when provided
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// HTTP status code of the response
+	StatusCode int `json:"-"`
+	// Value of the X-Ovh-Queryid response header, needed by OVH support
+	QueryID string `json:"-"`
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
-	return fmt.Sprintf("Error %d: %q", e.ErrorCode, e.Message)
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("OVH API error (HTTP %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("OVH API error (HTTP %d, code %s): %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// Is makes errors.Is(err, ErrNotFound) and siblings match on the HTTP status
+func (e *APIError) Is(target error) bool {
+	return target != nil && statusErrors[e.StatusCode] == target
+}
+
+// TransportError reports a failure to obtain a response from the API, such as
+// a network error, a timeout or a cancellation, as opposed to an APIError
+// returned by the API itself. It wraps the underlying error so that, for
+// instance, errors.Is(err, context.DeadlineExceeded) works as expected.
+type TransportError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *TransportError) Error() string {
+	return "ovh: transport error: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the request timed out
+func (e *TransportError) Timeout() bool {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// ConfigError reports a configuration file which could not be loaded
+type ConfigError struct {
+	// Path of the offending file
+	Path string
+	// Parsing error, which usually points at the offending line
+	Err error
+}
+
+// Error implements the error interface
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("ovh: invalid configuration file %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
 }
 
 // Util: get user home
@@ -105,80 +418,188 @@ func NewEndpointClient(endpoint string) (*Client, error) {
 
 // NewClient returns an OVH API Client.
 func NewClient(endpointName, applicationKey, applicationSecret, consumerKey string) (*Client, error) {
-	// Load configuration files by order of increasing priority. All configuration
-	// files are optional. Only load file from user home if home could be resolve
-	cfg := ini.Empty()
-	cfg.Append("/etc/ovh.conf")
-	if home, err := currentUserHome(); err == nil {
-		cfg.Append(home + "/.ovh.conf")
+	return NewClientWithOptions(
+		WithEndpoint(endpointName),
+		WithCredentials(applicationKey, applicationSecret, consumerKey),
+	)
+}
+
+// NewClientWithContext is like NewClient, but also synchronizes the time delta
+// with the API server right away, bound to ``ctx``, instead of before the first
+// authenticated request. Synchronization failures are returned.
+func NewClientWithContext(ctx context.Context, endpointName, applicationKey, applicationSecret, consumerKey string) (*Client, error) {
+	client, err := NewClient(endpointName, applicationKey, applicationSecret, consumerKey)
+	if err != nil {
+		return nil, err
 	}
-	cfg.Append("./ovh.conf")
 
-	// Canonicalize configuration
-	if endpointName == "" {
-		endpointName = getConfigValue(cfg, "default", "endpoint")
+	if _, err := client.loadTimeDelta(ctx); err != nil {
+		return nil, err
 	}
+	return client, nil
+}
 
-	// Check if the endpoint is now set
-	if endpointName == "" {
-		return nil, ErrNoEnpoint
+// NewClientWithOptions returns an OVH API Client configured with ``opts``.
+// Endpoint and credentials not provided as options are loaded from the
+// environment and the configuration files.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	transport := newTransport()
+	client := &Client{
+		Timeout:          time.Duration(DefaultTimeout * time.Second),
+		client:           &http.Client{Transport: transport},
+		transport:        transport,
+		userAgent:        DefaultUserAgent,
+		maxResponseBytes: DefaultMaxResponseBytes,
 	}
 
-	if applicationKey == "" {
-		applicationKey = getConfigValue(cfg, endpointName, "application_key")
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := client.configureTransport(); err != nil {
+		return nil, err
+	}
+
+	if err := client.loadConfig(); err != nil {
+		return nil, err
 	}
 
-	if applicationSecret == "" {
-		applicationSecret = getConfigValue(cfg, endpointName, "application_secret")
+	return client, nil
+}
+
+// loadConfig completes the endpoint and credentials with values from
+// the environment and configuration files, then resolves the endpoint URL
+func (c *Client) loadConfig() error {
+	// A base URL stands for the endpoint name, e.g. as config section
+	if c.endpointName == "" && c.baseURL != "" {
+		c.endpointName = string(c.baseURL)
 	}
+	c.endpointName = CanonicalEndpointName(c.endpointName)
+
+	// Skip configuration files entirely when everything was provided
+	if c.endpointName == "" || c.applicationKey == "" || c.applicationSecret == "" || c.consumerKey == "" {
+		cfg, err := c.loadConfigFiles()
+		if err != nil {
+			return err
+		}
+
+		// Canonicalize configuration. A profile may name its own endpoint
+		if c.endpointName == "" && c.profile != "" {
+			c.endpointName = getConfigValue(cfg, EnvEndpoint, c.profile, "endpoint")
+		}
+		if c.endpointName == "" {
+			c.endpointName = getConfigValue(cfg, EnvEndpoint, "default", "endpoint")
+		}
+
+		// Check if the endpoint is now set
+		if c.endpointName == "" {
+			return ErrNoEnpoint
+		}
+		c.endpointName = CanonicalEndpointName(c.endpointName)
+
+		// Credentials live in the profile section, or else in the endpoint one
+		section := c.profile
+		if section == "" {
+			section = c.endpointName
+		}
+
+		if c.applicationKey == "" {
+			c.applicationKey = getConfigValue(cfg, EnvApplicationKey, section, "application_key")
+		}
+
+		if c.applicationSecret == "" {
+			c.applicationSecret = getConfigValue(cfg, EnvApplicationSecret, section, "application_secret")
+		}
 
-	if consumerKey == "" {
-		consumerKey = getConfigValue(cfg, endpointName, "consumer_key")
+		if c.consumerKey == "" {
+			c.consumerKey = getConfigValue(cfg, EnvConsumerKey, section, "consumer_key")
+		}
 	}
 
-	// Load real endpoint URL by name. If endpoint contains a '/', consider it as a URL
-	var endpoint Endpoint
-	if strings.Contains(endpointName, "/") {
-		endpoint = Endpoint(endpointName)
+	// Load real endpoint URL by name. If endpoint contains a '/', it must be
+	// an absolute http(s) URL
+	named := false
+	if c.baseURL != "" {
+		c.endpoint = c.baseURL
+	} else if endpoint, ok := lookupEndpoint(c.endpointName); ok {
+		c.endpoint = endpoint
+		named = true
+	} else if strings.Contains(c.endpointName, "/") {
+		endpoint, err := parseEndpointURL(c.endpointName)
+		if err != nil {
+			return err
+		}
+		c.endpoint = endpoint
 	} else {
-		endpoint = Endpoints[endpointName]
+		return fmt.Errorf("ovh: unknown endpoint %q", c.endpointName)
 	}
 
-	// Timeout
-	timeout := time.Duration(DefaultTimeout * time.Second)
+	if c.oauth2 != nil {
+		if err := c.oauth2.resolveTokenURL(c.endpoint, c.oauth2TokenURL); err != nil {
+			return err
+		}
+	}
 
-	// Create client
-	client := &Client{
-		endpoint:          endpoint,
-		applicationKey:    applicationKey,
-		applicationSecret: applicationSecret,
-		consumerKey:       consumerKey,
-		Timeout:           timeout,
-		client:            &http.Client{},
+	if named && c.apiVersion != "" {
+		c.endpoint = withAPIVersion(c.endpoint, c.apiVersion)
 	}
 
-	return client, nil
+	return nil
 }
 
-// getConfigValue returns the value of OVH_<NAME> or ``name`` value from ``section``
-func getConfigValue(cfg *ini.File, section, name string) string {
+// withAPIVersion replaces the version segment ending ``endpoint``, if any,
+// with ``version``
+func withAPIVersion(endpoint Endpoint, version string) Endpoint {
+	base := string(endpoint)
+	if i := strings.LastIndex(base, "/"); i >= 0 && isAPIVersion(base[i+1:]) {
+		base = base[:i]
+	}
+	return Endpoint(base + "/" + version)
+}
+
+// isAPIVersion reports whether the path ``segment`` is an API version, e.g.
+// "1.0" or "v2"
+func isAPIVersion(segment string) bool {
+	segment = strings.TrimPrefix(segment, "v")
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if (r < '0' || r > '9') && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// loadConfigFiles returns the source of the configuration values: the one
+// given with WithCredentialSource, or else the INI configuration files. Files
+// explicitly given with WithConfigFile replace ConfigPaths.
+func (c *Client) loadConfigFiles() (CredentialSource, error) {
+	if c.credentialSource != nil {
+		return c.credentialSource, nil
+	}
+
+	paths := ConfigPaths
+	if len(c.configFiles) > 0 {
+		paths = c.configFiles
+	}
+	return NewINISource(paths...)
+}
+
+// getConfigValue returns the value of the ``env`` environment variable or
+// ``name`` value from ``section``
+func getConfigValue(source CredentialSource, env, section, name string) string {
 	// Attempt to load from environment
-	fromEnv := os.Getenv("OVH_" + strings.ToUpper(name))
+	fromEnv := os.Getenv(env)
 	if len(fromEnv) > 0 {
 		return fromEnv
 	}
 
 	// Attempt to load from configuration
-	fromSection := cfg.Section(section)
-	if fromSection == nil {
-		return ""
-	}
-
-	fromSectionKey := fromSection.Key(name)
-	if fromSectionKey == nil {
-		return ""
-	}
-	return fromSectionKey.String()
+	return source.Value(section, name)
 }
 
 //
@@ -194,141 +615,817 @@ func (r *APIResponse) DecodeError(expectedHTTPCode []int) (*APIError, error) {
 	}
 
 	// Decode OVH error informations from response
-	if r.Body != nil {
-		var ovhResponse *APIError
+	if len(r.Body) > 0 {
+		ovhResponse := &APIError{}
 		err := json.Unmarshal(r.Body, ovhResponse)
 		if err == nil {
-			return ovhResponse, errors.New(ovhResponse.Message)
+			ovhResponse.StatusCode = r.StatusCode
+			ovhResponse.QueryID = r.QueryID
+			return ovhResponse, ovhResponse
 		}
 	}
-	return nil, fmt.Errorf("%d - %s", r.StatusCode, r.Status)
+
+	// Not an OVH error, e.g. an error page of a proxy. Hint at its content
+	message := fmt.Sprintf("%d - %s", r.StatusCode, r.Status)
+	if snippet := bodySnippet(r.Body); snippet != "" {
+		message += ": " + snippet
+	}
+	if sentinel, ok := statusErrors[r.StatusCode]; ok {
+		return nil, fmt.Errorf("%s: %w", message, sentinel)
+	}
+	return nil, errors.New(message)
+}
+
+// maxBodySnippet bounds the length of the body quoted in errors
+const maxBodySnippet = 256
+
+// bodySnippet returns the beginning of ``body``, quoted, for error messages
+func bodySnippet(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if len(body) <= maxBodySnippet {
+		return strconv.Quote(string(body))
+	}
+
+	end := maxBodySnippet
+	for end > 0 && !utf8.RuneStart(body[end]) {
+		end--
+	}
+	return strconv.Quote(string(body[:end])) + "..."
+}
+
+// UnmarshalInto checks the response status and decodes the body into ``out``.
+// An empty body, as for 204 No Content, leaves ``out`` untouched. When ``out``
+// is nil, only the status is checked. Numbers decoded into an interface{} are
+// json.Number, so that large ids do not lose precision as a float64.
+//
+// Errors are prefixed with the method and path of the call, use errors.As to
+// get the *APIError.
+func (r *APIResponse) UnmarshalInto(out interface{}) error {
+	return wrapCallError(r.method, r.path, r.unmarshalInto(out))
+}
+
+// unmarshalInto is UnmarshalInto, without the request in errors
+func (r *APIResponse) unmarshalInto(out interface{}) error {
+	apiError, err := r.DecodeError([]int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent})
+	if apiError != nil {
+		return apiError
+	}
+	if err != nil {
+		return err
+	}
+	if out == nil || len(r.Body) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(r.Body))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+// wrapCallError prefixes ``err`` with the call it comes from, if known
+func wrapCallError(method, path string, err error) error {
+	if err == nil || method == "" {
+		return err
+	}
+	// Already names the call
+	var marshalErr *marshalError
+	if errors.As(err, &marshalErr) {
+		return err
+	}
+	return fmt.Errorf("%s %s: %w", method, path, err)
 }
 
 // Get Issues an authenticated get request on /path
 func (c *Client) Get(path string) (*APIResponse, error) {
-	return c.Call("GET", path, nil, true)
+	return c.GetWithContext(context.Background(), path)
 }
 
 // GetUnAuth Issues an un-authenticated get request on /path
 func (c *Client) GetUnAuth(path string) (*APIResponse, error) {
-	return c.Call("GET", path, nil, false)
+	return c.GetUnAuthWithContext(context.Background(), path)
 }
 
 // Post Issues an authenticated get request on /path
 func (c *Client) Post(path string, data interface{}) (*APIResponse, error) {
-	return c.Call("POST", path, data, true)
+	return c.PostWithContext(context.Background(), path, data)
 }
 
 // PostUnAuth Issues an un-authenticated get request on /path
 func (c *Client) PostUnAuth(path string, data interface{}) (*APIResponse, error) {
-	return c.Call("POST", path, data, false)
+	return c.PostUnAuthWithContext(context.Background(), path, data)
 }
 
 // Put Issues an authenticated get request on /path
 func (c *Client) Put(path string, data interface{}) (*APIResponse, error) {
-	return c.Call("PUT", path, data, true)
+	return c.PutWithContext(context.Background(), path, data)
 }
 
 // PutUnAuth Issues an un-authenticated get request on /path
 func (c *Client) PutUnAuth(path string, data interface{}) (*APIResponse, error) {
-	return c.Call("PUT", path, data, false)
+	return c.PutUnAuthWithContext(context.Background(), path, data)
 }
 
 // Delete Issues an authenticated get request on /path
 func (c *Client) Delete(path string) (*APIResponse, error) {
-	return c.Call("DELETE", path, nil, true)
+	return c.DeleteWithContext(context.Background(), path)
 }
 
 // DeleteUnAuth Issues an un-authenticated get request on /path
 func (c *Client) DeleteUnAuth(path string) (*APIResponse, error) {
-	return c.Call("DELETE", path, nil, false)
+	return c.DeleteUnAuthWithContext(context.Background(), path)
+}
+
+// DeleteWithBody Issues an authenticated delete request on /path, with ``data``
+// as JSON body, for the few endpoints expecting one. The body is signed as
+// for Post and Put.
+func (c *Client) DeleteWithBody(path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(context.Background(), "DELETE", path, data, true)
+}
+
+// Patch Issues an authenticated patch request on /path
+func (c *Client) Patch(path string, data interface{}) (*APIResponse, error) {
+	return c.PatchWithContext(context.Background(), path, data)
+}
+
+// PatchUnAuth Issues an un-authenticated patch request on /path
+func (c *Client) PatchUnAuth(path string, data interface{}) (*APIResponse, error) {
+	return c.PatchUnAuthWithContext(context.Background(), path, data)
+}
+
+// GetWithQuery Issues an authenticated get request on /path?params. The query
+// string is encoded and signed along with the path.
+func (c *Client) GetWithQuery(path string, params url.Values) (*APIResponse, error) {
+	return c.Get(pathWithQuery(path, params))
+}
+
+// GetInto Issues an authenticated get request on /path and decodes the response into ``out``
+func (c *Client) GetInto(path string, out interface{}) error {
+	return c.callInto("GET", path, nil, out)
+}
+
+// PostInto Issues an authenticated post request on /path and decodes the response into ``out``
+func (c *Client) PostInto(path string, data, out interface{}) error {
+	return c.callInto("POST", path, data, out)
+}
+
+// PutInto Issues an authenticated put request on /path and decodes the response into ``out``
+func (c *Client) PutInto(path string, data, out interface{}) error {
+	return c.callInto("PUT", path, data, out)
+}
+
+// GetArray Issues an authenticated get request on /path and decodes the
+// response as an array of strings, the usual shape of collections. On
+// unexpected HTTP code, the decoded *APIError is returned.
+func (c *Client) GetArray(path string) ([]string, error) {
+	items := []string{}
+	if err := c.GetInto(path, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetWithContext Issues an authenticated get request on /path, bound to ctx
+func (c *Client) GetWithContext(ctx context.Context, path string) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "GET", path, nil, true)
+}
+
+// GetUnAuthWithContext Issues an un-authenticated get request on /path, bound to ctx
+func (c *Client) GetUnAuthWithContext(ctx context.Context, path string) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "GET", path, nil, false)
+}
+
+// PostWithContext Issues an authenticated post request on /path, bound to ctx
+func (c *Client) PostWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "POST", path, data, true)
+}
+
+// PostUnAuthWithContext Issues an un-authenticated post request on /path, bound to ctx
+func (c *Client) PostUnAuthWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "POST", path, data, false)
+}
+
+// PutWithContext Issues an authenticated put request on /path, bound to ctx
+func (c *Client) PutWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "PUT", path, data, true)
+}
+
+// PutUnAuthWithContext Issues an un-authenticated put request on /path, bound to ctx
+func (c *Client) PutUnAuthWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "PUT", path, data, false)
+}
+
+// DeleteWithContext Issues an authenticated delete request on /path, bound to ctx
+func (c *Client) DeleteWithContext(ctx context.Context, path string) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "DELETE", path, nil, true)
+}
+
+// DeleteUnAuthWithContext Issues an un-authenticated delete request on /path, bound to ctx
+func (c *Client) DeleteUnAuthWithContext(ctx context.Context, path string) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "DELETE", path, nil, false)
+}
+
+// PatchWithContext Issues an authenticated patch request on /path, bound to ctx
+func (c *Client) PatchWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "PATCH", path, data, true)
+}
+
+// PatchUnAuthWithContext Issues an un-authenticated patch request on /path, bound to ctx
+func (c *Client) PatchUnAuthWithContext(ctx context.Context, path string, data interface{}) (*APIResponse, error) {
+	return c.CallWithContext(ctx, "PATCH", path, data, false)
 }
 
 //
 // Low Level Helpers
 //
 
+// pathWithQuery appends encoded ``params`` to ``path``
+func pathWithQuery(path string, params url.Values) string {
+	if len(params) == 0 {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + params.Encode()
+	}
+	return path + "?" + params.Encode()
+}
+
+// validateCredentials checks the credentials needed to sign requests are set
+func (c *Client) validateCredentials(opts *callOptions) error {
+	if c.oauth2 != nil {
+		return nil
+	}
+	if c.applicationKey == "" {
+		return ErrMissingApplicationKey
+	}
+	if c.applicationSecret == "" {
+		return ErrMissingApplicationSecret
+	}
+	if c.callConsumerKey(opts) == "" {
+		return ErrMissingConsumerKey
+	}
+	return nil
+}
+
+// callConsumerKey returns the consumer key signing a call, the one set with
+// AsConsumer, if any, or the one of the client
+func (c *Client) callConsumerKey(opts *callOptions) string {
+	if opts.consumerKey != "" {
+		return opts.consumerKey
+	}
+	return c.getConsumerKey()
+}
+
+// getConsumerKey returns the consumer key currently in use
+func (c *Client) getConsumerKey() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.consumerKey
+}
+
+// setConsumerKey switches the consumer key used by subsequent requests
+func (c *Client) setConsumerKey(consumerKey string) {
+	c.lock.Lock()
+	c.consumerKey = consumerKey
+	c.lock.Unlock()
+}
+
 // Account for clock delay in API in signatures
-func (c *Client) getTimeDelta() int64 {
-	if c.timeDeltaDone != true {
-		// Attempt to get timeDelta or fallback on 0
-		timeDelta, err := c.GetUnAuth("/auth/time")
-		if err != nil {
-			return 0
+func (c *Client) getTimeDelta(ctx context.Context) int64 {
+	// Attempt to get timeDelta or fallback on 0
+	timeDelta, err := c.loadTimeDelta(ctx)
+	if err != nil {
+		return 0
+	}
+	return timeDelta
+}
+
+// loadTimeDelta returns the time delta, synchronizing it first if needed
+func (c *Client) loadTimeDelta(ctx context.Context) (int64, error) {
+	c.lock.RLock()
+	timeDelta, done := c.timeDelta, c.timeDeltaDone
+	c.lock.RUnlock()
+
+	if !done {
+		// Reuse the delta recently computed by another client, if any
+		if timeDelta, ok := c.cachedTimeDelta(); ok {
+			c.setTimeDelta(timeDelta)
+			return timeDelta, nil
 		}
 
-		// Attempt to load timeDelta or fallback on 0
-		var serverTime int64
-		err = json.Unmarshal(timeDelta.Body, &serverTime)
-		if err != nil {
-			return 0
+		if err := c.refreshTimeDelta(ctx); err != nil {
+			return 0, err
 		}
-		c.timeDelta = time.Now().Unix() - serverTime
-		c.timeDeltaDone = true
+		return c.loadTimeDelta(ctx)
+	}
+	return timeDelta, nil
+}
+
+// ServerTime returns the current time of the API server, that is the local
+// time corrected by the time delta. The delta is synchronized first if needed.
+func (c *Client) ServerTime() (time.Time, error) {
+	timeDelta, err := c.loadTimeDelta(context.Background())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.clock().Add(-time.Duration(timeDelta) * time.Second), nil
+}
+
+// RefreshTimeDelta synchronizes the signature clock with the API server. The
+// first authenticated call does it automatically, long-lived clients may call
+// it periodically to follow local clock adjustments.
+func (c *Client) RefreshTimeDelta() error {
+	return c.refreshTimeDelta(context.Background())
+}
+
+// AuthTime returns the current time of the API server, as a Unix timestamp,
+// fetched from the unauthenticated /auth/time. Unlike ServerTime, it always
+// makes the round-trip.
+func (c *Client) AuthTime() (int64, error) {
+	return c.authTime(context.Background())
+}
+
+// authTime is AuthTime, bound to ``ctx``
+func (c *Client) authTime(ctx context.Context) (int64, error) {
+	response, err := c.GetUnAuthWithContext(ctx, "/auth/time")
+	if err != nil {
+		return 0, err
+	}
+
+	var serverTime int64
+	if err := response.UnmarshalInto(&serverTime); err != nil {
+		return 0, err
+	}
+	return serverTime, nil
+}
+
+// refreshTimeDelta loads the time delta from /auth/time, bound to ``ctx``
+func (c *Client) refreshTimeDelta(ctx context.Context) error {
+	serverTime, err := c.authTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Positive when the local clock is ahead of the server
+	timeDelta := c.clock().Unix() - serverTime
+	c.setTimeDelta(timeDelta)
+	if c.now == nil {
+		storeTimeDelta(c.endpoint, timeDelta)
+	}
+	return nil
+}
+
+// resyncOnInvalidSignature synchronizes the time delta again when the
+// signature of a call was rejected. It reports whether the call is worth
+// sending again.
+func (c *Client) resyncOnInvalidSignature(ctx context.Context, needAuth bool, response *APIResponse) bool {
+	if !needAuth || c.oauth2 != nil || c.disableClockSync || response == nil || response.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if apiError, _ := response.DecodeError(nil); apiError == nil || apiError.ErrorCode != "INVALID_SIGNATURE" {
+		return false
+	}
+	return c.refreshTimeDelta(ctx) == nil
+}
+
+// setTimeDelta records the time delta used to sign requests
+func (c *Client) setTimeDelta(timeDelta int64) {
+	c.lock.Lock()
+	c.timeDelta = timeDelta
+	c.timeDeltaDone = true
+	c.lock.Unlock()
+}
+
+// callInto runs an authenticated call and decodes a successful response into
+// ``out``. On unexpected HTTP code, the decoded APIError is returned instead.
+func (c *Client) callInto(method, path string, data, out interface{}) error {
+	response, err := c.Call(method, path, data, true)
+	if err != nil {
+		return err
 	}
-	return c.timeDelta
+	return response.UnmarshalInto(out)
 }
 
-// Call calls OVH's API and signs the request if ``needAuth`` is ``true``
+// Call calls OVH's API and signs the request if ``needAuth`` is ``true``.
+// ``data`` is marshalled to JSON, unless it is a json.RawMessage or a []byte,
+// sent as is.
+//
+// A response is returned whatever its HTTP status, use DecodeError or
+// UnmarshalInto to check it. Failures to get a response at all are reported
+// as a *TransportError, check them with errors.As. Errors are prefixed with
+// ``method`` and ``path``.
+//
+// With OAuth2, a 401 Unauthorized response renews the token and the call is
+// sent once more. Otherwise, a 401 means the consumer key is invalid, expired
+// or revoked: errors.Is(err, ErrUnauthorized) holds for the error returned by
+// UnmarshalInto, and a new consumer key must be requested.
 func (c *Client) Call(method, path string, data interface{}, needAuth bool) (*APIResponse, error) {
-	var body []byte
-	var err error
+	return c.CallWithContext(context.Background(), method, path, data, needAuth)
+}
 
-	if data != nil {
-		body, err = json.Marshal(data)
-		if err != nil {
+// CallWithContext is like Call, but the request is bound to ``ctx``. Cancelling
+// ``ctx`` aborts the request, in which case a *TransportError wrapping
+// ``ctx.Err()`` is returned. A deadline on ``ctx`` shorter than c.Timeout
+// overrides it for this call.
+func (c *Client) CallWithContext(ctx context.Context, method, path string, data interface{}, needAuth bool) (*APIResponse, error) {
+	return c.CallWithOptions(ctx, method, path, data, needAuth)
+}
+
+// callWithOptions marshals ``data`` and runs the API call, retrying as
+// configured in c.Retry
+func (c *Client) callWithOptions(ctx context.Context, method, path string, data interface{}, needAuth bool, opts *callOptions) (*APIResponse, error) {
+	start := time.Now()
+
+	response, err := c.callWithRetries(ctx, method, path, data, needAuth, opts)
+	if response != nil {
+		c.observe(method, path, start, response.StatusCode, err)
+		response.method = method
+		response.path = path
+	} else {
+		c.observe(method, path, start, 0, err)
+	}
+	return response, wrapCallError(method, path, err)
+}
+
+// callWithRetries runs the API call, retrying transient failures as
+// configured in c.Retry
+func (c *Client) callWithRetries(ctx context.Context, method, path string, data interface{}, needAuth bool, opts *callOptions) (*APIResponse, error) {
+	body, err := c.prepareCall(method, path, data, needAuth, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotent := isIdempotent(method) || opts.headers.Get(idempotencyKeyHeader) != ""
+	tokenRenewed, clockSynced := false, false
+	sent := 0
+	for attempt := 1; ; attempt++ {
+		response, err := c.call(ctx, method, path, body, needAuth, opts)
+		sent++
+		if response != nil {
+			response.Attempts = sent
+		}
+
+		// An OAuth2 token may be revoked before it expires, renew it once
+		if !tokenRenewed && c.invalidateOAuth2Token(needAuth, response) {
+			tokenRenewed = true
+			attempt--
+			continue
+		}
+
+		// A rejected signature most likely comes from a drifting local
+		// clock, synchronize it once
+		if !clockSynced && c.resyncOnInvalidSignature(ctx, needAuth, response) {
+			clockSynced = true
+			attempt--
+			continue
+		}
+
+		if !c.Retry.shouldRetry(ctx, idempotent, attempt, response, err) {
+			return response, err
+		}
+		if !c.Retry.wait(ctx, attempt, response) {
+			return response, err
+		}
+	}
+}
+
+// CallFull is like CallWithContext, but returns the underlying *http.Response.
+// Its body is already buffered, closing it is not required.
+func (c *Client) CallFull(ctx context.Context, method, path string, data interface{}, needAuth bool) (*http.Response, error) {
+	response, err := c.CallWithContext(ctx, method, path, data, needAuth)
+	if err != nil {
+		return nil, err
+	}
+	return response.HTTPResponse(), nil
+}
+
+// CallStream is like Call, but returns the response body unread, along with
+// the HTTP status code, so that large responses can be streamed. The caller
+// is responsible for closing the returned reader. Failures are not retried.
+func (c *Client) CallStream(method, path string, data interface{}, needAuth bool) (io.ReadCloser, int, error) {
+	start := time.Now()
+
+	r, err := c.callStream(method, path, data, needAuth)
+	if err != nil {
+		c.observe(method, path, start, 0, err)
+		return nil, 0, wrapCallError(method, path, err)
+	}
+	c.observe(method, path, start, r.StatusCode, nil)
+
+	return r.Body, r.StatusCode, nil
+}
+
+// callStream sends the request of CallStream
+func (c *Client) callStream(method, path string, data interface{}, needAuth bool) (*http.Response, error) {
+	opts := &callOptions{}
+	body, err := c.prepareCall(method, path, data, needAuth, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(context.Background(), method, path, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.send(req, body, needAuth, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.debug(r.Request, body, r, nil)
+
+	return r, nil
+}
+
+// prepareCall checks a call can be issued and marshals its body
+func (c *Client) prepareCall(method, path string, data interface{}, needAuth bool, opts *callOptions) ([]byte, error) {
+	// Fail early rather than sending a request bound to be rejected
+	if needAuth {
+		if err := c.validateCredentials(opts); err != nil {
 			return nil, err
 		}
 	}
 
-	target := fmt.Sprintf("%s%s", c.endpoint, path)
-	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	// Pre-serialized bodies are sent, and signed, verbatim
+	switch body := data.(type) {
+	case nil:
+		return nil, nil
+	case json.RawMessage:
+		return body, nil
+	case []byte:
+		return body, nil
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, &marshalError{method: method, path: path, err: err}
+	}
+	return body, nil
+}
+
+// marshalError reports a request body which could not be marshalled
+type marshalError struct {
+	method string
+	path   string
+	err    error
+}
+
+// Error implements the error interface
+func (e *marshalError) Error() string {
+	return fmt.Sprintf("failed to marshal body for %s %s: %v", e.method, e.path, e.err)
+}
+
+// Unwrap returns the json.Marshal error
+func (e *marshalError) Unwrap() error {
+	return e.err
+}
+
+// call runs a single attempt of an API call with an already marshalled body,
+// or the streamed one of CallReader
+func (c *Client) call(ctx context.Context, method, path string, body []byte, needAuth bool, opts *callOptions) (*APIResponse, error) {
+	var req *http.Request
+	var err error
+	if opts.stream != nil {
+		req, err = c.newStreamRequest(ctx, method, path, opts)
+	} else {
+		req, err = c.newRequest(ctx, method, path, body, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	return c.do(req, body, needAuth, opts)
+}
+
+// newRequest builds the request of an API call with an already marshalled body
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte, opts *callOptions) (*http.Request, error) {
+	var reader io.Reader
 	if body != nil {
-		req.Header.Add("Content-Type", "application/json;charset=utf-8")
+		reader = bytes.NewReader(body)
 	}
-	req.Header.Add("X-Ovh-Application", c.applicationKey)
+	return c.newBodyRequest(ctx, method, path, reader, opts)
+}
 
-	// Some methods do not need authentication, especially /time, /auth and some
-	// /order methods are actually broken if authenticated.
+// callContentType returns the type of the request body of a call
+func (c *Client) callContentType(opts *callOptions) string {
+	if opts.contentType != "" {
+		return opts.contentType
+	}
+	if c.contentType != "" {
+		return c.contentType
+	}
+	return DefaultContentType
+}
+
+// requestURL returns the URL of a request to ``path``, escaped as sent, and
+// signed
+func (c *Client) requestURL(path string) (string, error) {
+	u, err := url.Parse(string(c.endpoint) + path)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// newBodyRequest builds the request of an API call reading its body from
+// ``body``, if not nil
+func (c *Client) newBodyRequest(ctx context.Context, method, path string, body io.Reader, opts *callOptions) (*http.Request, error) {
+	target, err := c.requestURL(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range opts.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if body != nil && !opts.noContentType && req.Header.Get("Content-Type") == "" {
+		req.Header.Add("Content-Type", c.callContentType(opts))
+	}
+
+	return req, nil
+}
+
+// Do sends a pre-built request, after setting the OVH headers and, if
+// ``needAuth`` is ``true``, signing it from its method, URL and body. The
+// body is read in memory to that end. Unlike Call, failures are not retried.
+func (c *Client) Do(req *http.Request, needAuth bool) (*APIResponse, error) {
+	opts := &callOptions{}
 	if needAuth {
-		timestamp := time.Now().Unix() - c.getTimeDelta()
+		if err := c.validateCredentials(opts); err != nil {
+			return nil, err
+		}
+	}
 
-		req.Header.Add("X-Ovh-Timestamp", fmt.Sprintf("%d", timestamp))
-		req.Header.Add("X-Ovh-Consumer", c.consumerKey)
-		req.Header.Add("Accept", "application/json")
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
 
-		h := sha1.New()
-		h.Write([]byte(fmt.Sprintf("%s+%s+%s+%s+%s+%d",
-			c.applicationSecret,
-			c.consumerKey,
-			method,
-			target,
-			body,
-			timestamp,
-		)))
-		req.Header.Add("X-Ovh-Signature", fmt.Sprintf("$1$%x", h.Sum(nil)))
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
 	}
 
-	c.client.Timeout = c.Timeout
-	r, err := c.client.Do(req)
+	return c.do(req, body, needAuth, opts)
+}
 
+// do sends ``req``, whose body is ``body``, and buffers the response
+func (c *Client) do(req *http.Request, body []byte, needAuth bool, opts *callOptions) (*APIResponse, error) {
+	r, err := c.send(req, body, needAuth, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
-	response, err := ioutil.ReadAll(r.Body)
+	response, err := c.readBody(r.Body)
 	if err != nil {
 		return nil, err
 	}
+	response = toUTF8(r.Header.Get("Content-Type"), response)
+	c.debug(r.Request, body, r, response)
+	r.Body = ioutil.NopCloser(bytes.NewReader(response))
 
 	return &APIResponse{
 		StatusCode: r.StatusCode,
 		Status:     r.Status,
 		Body:       response,
+		QueryID:    r.Header.Get("X-Ovh-Queryid"),
+		ETag:       r.Header.Get("ETag"),
+		RateLimit:  parseRateLimit(r.Header),
+		Header:     r.Header,
+		Attempts:   1,
+		response:   r,
 	}, nil
 }
+
+// readBody buffers a response body, up to c.maxResponseBytes
+func (c *Client) readBody(body io.Reader) ([]byte, error) {
+	if c.maxResponseBytes <= 0 {
+		response, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, &TransportError{Err: err}
+		}
+		return response, nil
+	}
+
+	response, err := ioutil.ReadAll(io.LimitReader(body, c.maxResponseBytes+1))
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+	if int64(len(response)) > c.maxResponseBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, c.maxResponseBytes)
+	}
+	return response, nil
+}
+
+// send sets the OVH headers on ``req``, whose body is ``body``, and sends it.
+// Closing the response body releases the request timeout.
+func (c *Client) send(req *http.Request, body []byte, needAuth bool, opts *callOptions) (*http.Response, error) {
+	// Apply the timeout through the context rather than on the shared
+	// http.Client, which may be used concurrently
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if c.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		req = req.WithContext(ctx)
+	}
+
+	// Headers already set come from the caller, and take precedence
+	req.Header.Set("X-Ovh-Application", c.applicationKey)
+	if c.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	c.setAcceptEncoding(req)
+	if needAuth && !opts.noAccept && req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	// Requests skipped in dry run mode touch neither the network, to sign
+	// them, nor the rate limiter
+	if c.skipInDryRun(req, needAuth) {
+		cancel()
+		return dryRunResponse(req), nil
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			cancel()
+			return nil, &TransportError{Err: err}
+		}
+	}
+
+	// Some methods do not need authentication, especially /time, /auth and some
+	// /order methods are actually broken if authenticated.
+	if needAuth && c.oauth2 != nil {
+		token, err := c.oauth2.getToken(ctx, c.client)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if needAuth {
+		timestamp := c.clock().Unix() - c.getTimeDelta(ctx)
+		consumerKey := c.callConsumerKey(opts)
+
+		req.Header.Set("X-Ovh-Timestamp", fmt.Sprintf("%d", timestamp))
+		req.Header.Set("X-Ovh-Consumer", consumerKey)
+		signature := c.sign(consumerKey, req.Method, req.URL.String(), body, timestamp)
+		if opts.stream != nil {
+			var err error
+			signature, err = c.signStream(consumerKey, req.Method, req.URL.String(), opts.stream, timestamp)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+		req.Header.Set("X-Ovh-Signature", signature)
+	}
+
+	r, err := c.client.Do(req)
+
+	if err != nil {
+		c.debug(req, body, nil, nil)
+
+		// Surface cancellation as is, rather than wrapped in an *url.Error
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		cancel()
+		return nil, &TransportError{Err: err}
+	}
+
+	r.Body = &cancelOnClose{ReadCloser: r.Body, cancel: cancel}
+	if err := decompress(r); err != nil {
+		r.Body.Close()
+		return nil, &TransportError{Err: err}
+	}
+	return r, nil
+}
+
+// cancelOnClose releases a request context once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements the io.Closer interface
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,349 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeErrorAPIError(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ovh-Queryid", "EU.ext-1.5f0e.1234")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errorCode":"INVALID_CREDENTIAL","httpCode":"403 Forbidden","message":"This credential is not valid"}`))
+	})
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	apiError, err := response.DecodeError([]int{http.StatusOK})
+	if apiError == nil {
+		t.Fatalf("DecodeError returned no *APIError, err: %v", err)
+	}
+	if err != apiError {
+		t.Errorf("DecodeError error is %v, want the *APIError", err)
+	}
+
+	want := APIError{
+		ErrorCode:  "INVALID_CREDENTIAL",
+		HTTPCode:   "403 Forbidden",
+		Message:    "This credential is not valid",
+		StatusCode: http.StatusForbidden,
+		QueryID:    "EU.ext-1.5f0e.1234",
+	}
+	if apiError.ErrorCode != want.ErrorCode || apiError.HTTPCode != want.HTTPCode || apiError.Message != want.Message ||
+		apiError.StatusCode != want.StatusCode || apiError.QueryID != want.QueryID {
+		t.Errorf("DecodeError = %+v, want %+v", *apiError, want)
+	}
+
+	wantMessage := "OVH API error (HTTP 403, code INVALID_CREDENTIAL): This credential is not valid"
+	if apiError.Error() != wantMessage {
+		t.Errorf("Error() = %q, want %q", apiError.Error(), wantMessage)
+	}
+	if !errors.Is(apiError, ErrForbidden) {
+		t.Error("errors.Is(err, ErrForbidden) = false, want true")
+	}
+}
+
+func TestDecodeErrorExpectedStatus(t *testing.T) {
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"errorCode":"IGNORED"}`))
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if apiError, err := response.DecodeError([]int{http.StatusOK}); apiError != nil || err != nil {
+		t.Errorf("DecodeError = %v, %v, want nil, nil", apiError, err)
+	}
+}
+
+func TestCallTruncatedBody(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack: %v", err)
+			return
+		}
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"partial\":")
+		buf.Flush()
+		conn.Close()
+	})
+
+	response, err := client.Get("/me")
+	if err == nil {
+		t.Fatalf("Get = %q, want an error", response.Body)
+	}
+	var transportError *TransportError
+	if !errors.As(err, &transportError) {
+		t.Errorf("Get error = %v, want a *TransportError", err)
+	}
+}
+
+// Patch sends and signs its JSON body as Post and Put do
+func TestPatchBody(t *testing.T) {
+	data := map[string]string{"description": "patched"}
+	calls := []struct {
+		method string
+		call   func(*Client) (*APIResponse, error)
+	}{
+		{"POST", func(c *Client) (*APIResponse, error) { return c.Post("/me", data) }},
+		{"PUT", func(c *Client) (*APIResponse, error) { return c.Put("/me", data) }},
+		{"PATCH", func(c *Client) (*APIResponse, error) { return c.Patch("/me", data) }},
+	}
+	for _, call := range calls {
+		client, recorded := newRecordingClient(t)
+		if _, err := call.call(client); err != nil {
+			t.Fatalf("%s: %v", call.method, err)
+		}
+		if recorded.Method != call.method {
+			t.Errorf("method = %s, want %s", recorded.Method, call.method)
+		}
+		if got, want := string(recorded.Body), `{"description":"patched"}`; got != want {
+			t.Errorf("%s body = %s, want %s", call.method, got, want)
+		}
+		if got := recorded.Header.Get("Content-Type"); got != "application/json;charset=utf-8" {
+			t.Errorf("%s Content-Type = %q, want application/json;charset=utf-8", call.method, got)
+		}
+		checkSignature(t, client, recorded)
+	}
+}
+
+// Success responses without content are not decoded
+func TestUnmarshalIntoNoContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"204", http.StatusNoContent},
+		{"empty 200", http.StatusOK},
+	}
+	for _, tt := range tests {
+		client, _ := newTestClient(t, respond(tt.status, ""))
+
+		response, err := client.Delete("/me/sshKey/key")
+		if err != nil {
+			t.Fatalf("%s: Delete: %v", tt.name, err)
+		}
+		if apiError, err := response.DecodeError([]int{http.StatusOK, http.StatusNoContent}); apiError != nil || err != nil {
+			t.Errorf("%s: DecodeError = %v, %v, want nil", tt.name, apiError, err)
+		}
+
+		out := map[string]interface{}{"untouched": true}
+		if err := response.UnmarshalInto(&out); err != nil {
+			t.Errorf("%s: UnmarshalInto: %v", tt.name, err)
+		}
+		if len(out) != 1 || out["untouched"] != true {
+			t.Errorf("%s: UnmarshalInto modified out: %v", tt.name, out)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	req, err := http.NewRequest("POST", string(client.endpoint)+"/me/sshKey", strings.NewReader(`{"key":"ssh-rsa"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Custom", "value")
+	if _, err := client.Do(req, true); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if got := recorded.Header.Get("X-Custom"); got != "value" {
+		t.Errorf("X-Custom = %q, want value", got)
+	}
+	checkSignature(t, client, recorded)
+}
+
+func TestDoMissingCredentials(t *testing.T) {
+	client, recorded := newRecordingClient(t, WithCredentials(testApplicationKey, testApplicationSecret, ""))
+
+	req, err := http.NewRequest("GET", string(client.endpoint)+"/me", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req, true); !errors.Is(err, ErrMissingConsumerKey) {
+		t.Errorf("Do: got %v, want ErrMissingConsumerKey", err)
+	}
+	if recorded.Method != "" {
+		t.Errorf("request sent without credentials")
+	}
+}
+
+// IDs above 2^53 do not lose precision when decoded into interface{}
+func TestUnmarshalIntoLargeID(t *testing.T) {
+	const id = "9007199254740993" // 2^53 + 1
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"id":`+id+`}`))
+
+	var out map[string]interface{}
+	if err := client.GetInto("/me/task/1", &out); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	number, ok := out["id"].(json.Number)
+	if !ok {
+		t.Fatalf("id is a %T, want json.Number", out["id"])
+	}
+	if number.String() != id {
+		t.Errorf("id = %s, want %s", number, id)
+	}
+
+	var typed struct{ ID int64 }
+	if err := client.GetInto("/me/task/1", &typed); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	if typed.ID != 9007199254740993 {
+		t.Errorf("ID = %d, want %s", typed.ID, id)
+	}
+}
+
+// DeleteWithBody sends and signs its JSON body
+func TestDeleteWithBody(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	if _, err := client.DeleteWithBody("/ip/1.2.3.4/firewall", map[string]bool{"force": true}); err != nil {
+		t.Fatalf("DeleteWithBody: %v", err)
+	}
+	if recorded.Method != "DELETE" {
+		t.Errorf("method = %s, want DELETE", recorded.Method)
+	}
+	if got, want := string(recorded.Body), `{"force":true}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+	checkSignature(t, client, recorded)
+
+	// The signature covers the body
+	timestamp, _ := strconv.ParseInt(recorded.Header.Get("X-Ovh-Timestamp"), 10, 64)
+	if client.Signature("DELETE", recorded.Target, nil, timestamp) == recorded.Header.Get("X-Ovh-Signature") {
+		t.Error("signature does not cover the body")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}, WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.Get("/me")
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get returned after %s", elapsed)
+	}
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || !transportErr.Timeout() {
+		t.Fatalf("Get error = %v, want a timeout *TransportError", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// Pre-serialized bodies are sent and signed verbatim, not marshalled again
+func TestPreSerializedBody(t *testing.T) {
+	const body = `{"description": "kept as is"}`
+	for _, data := range []interface{}{json.RawMessage(body), []byte(body)} {
+		client, recorded := newRecordingClient(t)
+		if _, err := client.Post("/me/sshKey", data); err != nil {
+			t.Fatalf("Post %T: %v", data, err)
+		}
+		if string(recorded.Body) != body {
+			t.Errorf("%T body = %s, want %s", data, recorded.Body, body)
+		}
+		checkSignature(t, client, recorded)
+	}
+}
+
+// Oversized responses fail without being retried
+func TestMaxResponseBytes(t *testing.T) {
+	requests := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"description":"` + strings.Repeat("x", 100) + `"}`))
+	}, WithMaxResponseBytes(64))
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	_, err := client.Get("/me")
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Get error = %v, want ErrResponseTooLarge", err)
+	}
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		t.Errorf("Get error %v is a *TransportError", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests sent, want 1", requests)
+	}
+
+	// A body within the limit is read in full
+	client.maxResponseBytes = 1024
+	if _, err := client.Get("/me"); err != nil {
+		t.Errorf("Get within the limit: %v", err)
+	}
+}
+
+func TestMarshalError(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	_, err := client.Post("/me/sshKey", map[string]interface{}{"key": make(chan int)})
+	if err == nil {
+		t.Fatal("Post accepted a channel")
+	}
+	if want := "failed to marshal body for POST /me/sshKey"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error = %q, want it to start with %q", err, want)
+	}
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("error %v does not wrap the json error", err)
+	}
+	if recorded.Method != "" {
+		t.Error("request sent despite the marshal error")
+	}
+}
+
+// Cancelling the context aborts an in-flight request promptly
+func TestCallWithContextCancel(t *testing.T) {
+	started := make(chan struct{})
+	disconnected := make(chan struct{})
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(disconnected)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := client.CallWithContext(ctx, "GET", "/me", nil, true)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("CallWithContext returned after %s", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CallWithContext error = %v, want context.Canceled", err)
+	}
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Errorf("CallWithContext error = %v, want a *TransportError", err)
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(5 * time.Second):
+		t.Error("the server did not see the client disconnect")
+	}
+}
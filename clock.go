@@ -0,0 +1,82 @@
+package ovh
+
+import (
+	"sync"
+	"time"
+)
+
+// TimeDeltaCacheTTL is how long a time delta computed from /auth/time is
+// reused by the other clients of the process targeting the same endpoint,
+// sparing them the round-trip. 0 disables the cache.
+var TimeDeltaCacheTTL = 5 * time.Minute
+
+// cachedDelta is a time delta computed at some point in time
+type cachedDelta struct {
+	timeDelta int64
+	at        time.Time
+}
+
+// Process wide time delta cache, by endpoint
+var (
+	timeDeltaCacheLock sync.Mutex
+	timeDeltaCache     = map[Endpoint]cachedDelta{}
+)
+
+// WithClockSync controls the synchronization of the signature clock with the
+// API server. When ``enabled`` is false, /auth/time is never queried and the
+// local clock is trusted, as with WithTimeDelta(0). When ``force`` is true,
+// the client queries /auth/time itself instead of reusing a delta recently
+// computed by another client of the process.
+func WithClockSync(enabled, force bool) Option {
+	return func(c *Client) error {
+		if !enabled {
+			c.disableClockSync = true
+			return WithTimeDelta(0)(c)
+		}
+		c.forceClockSync = force
+		return nil
+	}
+}
+
+// cachedTimeDelta returns the delta recently computed for the client endpoint
+func (c *Client) cachedTimeDelta() (int64, bool) {
+	// A delta computed against another clock means nothing for this one
+	if c.forceClockSync || c.now != nil {
+		return 0, false
+	}
+
+	timeDeltaCacheLock.Lock()
+	defer timeDeltaCacheLock.Unlock()
+
+	cached, ok := timeDeltaCache[c.endpoint]
+	if !ok || time.Since(cached.at) >= TimeDeltaCacheTTL {
+		return 0, false
+	}
+	return cached.timeDelta, true
+}
+
+// storeTimeDelta shares ``timeDelta`` with the other clients of ``endpoint``
+func storeTimeDelta(endpoint Endpoint, timeDelta int64) {
+	timeDeltaCacheLock.Lock()
+	timeDeltaCache[endpoint] = cachedDelta{timeDelta: timeDelta, at: time.Now()}
+	timeDeltaCacheLock.Unlock()
+}
+
+// WithClock replaces the local clock the signature timestamps are derived
+// from, e.g. to reproduce signatures deterministically. The time delta with
+// the API server still applies on top of it, and is neither reused from nor
+// shared with the other clients of the process.
+func WithClock(now func() time.Time) Option {
+	return func(c *Client) error {
+		c.now = now
+		return nil
+	}
+}
+
+// clock returns the current local time
+func (c *Client) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
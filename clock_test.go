@@ -0,0 +1,60 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Signed timestamps match the server clock however the local one is skewed
+func TestClockSkew(t *testing.T) {
+	const serverTime = 1700000000
+	var signed []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/time") {
+			fmt.Fprintf(w, "%d", serverTime)
+			return
+		}
+		timestamp, _ := strconv.ParseInt(r.Header.Get("X-Ovh-Timestamp"), 10, 64)
+		signed = append(signed, timestamp)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	// All clients share the endpoint, and so would share the delta cache
+	for _, skew := range []int64{90, -90, 0, 3600, -3600} {
+		local := time.Unix(serverTime+skew, 0)
+		client, err := NewClientWithOptions(
+			WithBaseURL(server.URL),
+			WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+			WithClock(func() time.Time { return local }),
+		)
+		if err != nil {
+			t.Fatalf("NewClientWithOptions: %v", err)
+		}
+
+		signed = nil
+		if err := client.GetInto("/me", nil); err != nil {
+			t.Fatalf("skew %d: GetInto: %v", skew, err)
+		}
+		if len(signed) != 1 || signed[0] != serverTime {
+			t.Errorf("skew %d: signed timestamps %v, want [%d]", skew, signed, serverTime)
+		}
+		if timeDelta := client.getTimeDelta(context.Background()); timeDelta != skew {
+			t.Errorf("skew %d: time delta = %d", skew, timeDelta)
+		}
+
+		serverNow, err := client.ServerTime()
+		if err != nil {
+			t.Fatalf("skew %d: ServerTime: %v", skew, err)
+		}
+		if serverNow.Unix() != serverTime {
+			t.Errorf("skew %d: ServerTime = %d, want %d", skew, serverNow.Unix(), serverTime)
+		}
+	}
+}
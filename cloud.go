@@ -0,0 +1,111 @@
+package ovh
+
+// CloudInstance represents a Public Cloud instance
+type CloudInstance struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Region   string `json:"region"`
+	FlavorID string `json:"flavorId"`
+	ImageID  string `json:"imageId"`
+	SSHKeyID string `json:"sshKeyId,omitempty"`
+	// e.g. "BUILD", "ACTIVE" or "ERROR"
+	Status      string            `json:"status"`
+	Created     string            `json:"created"`
+	IPAddresses []CloudInstanceIP `json:"ipAddresses"`
+	// Set when the instance is billed monthly rather than hourly
+	MonthlyBilling *struct {
+		Since  string `json:"since"`
+		Status string `json:"status"`
+	} `json:"monthlyBilling,omitempty"`
+}
+
+// CloudInstanceIP represents an IP address of a Public Cloud instance
+type CloudInstanceIP struct {
+	IP string `json:"ip"`
+	// "public" or "private"
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+}
+
+// CloudInstanceSpec holds the parameters of a new Public Cloud instance
+type CloudInstanceSpec struct {
+	Name     string `json:"name"`
+	Region   string `json:"region"`
+	FlavorID string `json:"flavorId"`
+	ImageID  string `json:"imageId"`
+	SSHKeyID string `json:"sshKeyId,omitempty"`
+	// Cloud-init user data, if any
+	UserData       string `json:"userData,omitempty"`
+	MonthlyBilling bool   `json:"monthlyBilling,omitempty"`
+}
+
+// CloudFlavor represents a Public Cloud instance flavor
+type CloudFlavor struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Region string `json:"region"`
+	VCPUs  int    `json:"vcpus"`
+	// Memory in GB
+	RAM int `json:"ram"`
+	// Disk size in GB
+	Disk      int    `json:"disk"`
+	OSType    string `json:"osType"`
+	Available bool   `json:"available"`
+}
+
+// CloudService wraps the /cloud/project Public Cloud endpoints
+type CloudService struct {
+	client *Client
+}
+
+// Cloud returns a helper for the /cloud/project Public Cloud endpoints
+func (c *Client) Cloud() *CloudService {
+	return &CloudService{client: c}
+}
+
+// ListProjects returns the ids of the Public Cloud projects
+func (s *CloudService) ListProjects() ([]string, error) {
+	return s.client.GetArray("/cloud/project")
+}
+
+// ListRegions returns the regions available to ``projectID``
+func (s *CloudService) ListRegions(projectID string) ([]string, error) {
+	return s.client.GetArray(BuildPath("/cloud/project/{serviceName}/region", projectID))
+}
+
+// ListFlavors returns the instance flavors available to ``projectID``
+func (s *CloudService) ListFlavors(projectID string) ([]CloudFlavor, error) {
+	flavors := []CloudFlavor{}
+	if err := s.client.GetInto(BuildPath("/cloud/project/{serviceName}/flavor", projectID), &flavors); err != nil {
+		return nil, err
+	}
+	return flavors, nil
+}
+
+// ListInstances returns the instances of ``projectID``
+func (s *CloudService) ListInstances(projectID string) ([]CloudInstance, error) {
+	instances := []CloudInstance{}
+	if err := s.client.GetInto(BuildPath("/cloud/project/{serviceName}/instance", projectID), &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// GetInstance returns the instance ``instanceID`` of ``projectID``
+func (s *CloudService) GetInstance(projectID, instanceID string) (*CloudInstance, error) {
+	instance := &CloudInstance{}
+	if err := s.client.GetInto(BuildPath("/cloud/project/{serviceName}/instance/{instanceId}", projectID, instanceID), instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// CreateInstance creates an instance in ``projectID`` and returns it as
+// created. It is usable once its Status is "ACTIVE".
+func (s *CloudService) CreateInstance(projectID string, spec CloudInstanceSpec) (*CloudInstance, error) {
+	instance := &CloudInstance{}
+	if err := s.client.PostInto(BuildPath("/cloud/project/{serviceName}/instance", projectID), spec, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
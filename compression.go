@@ -0,0 +1,82 @@
+package ovh
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithCompression controls whether responses are requested compressed, with
+// gzip or deflate, and transparently decompressed, which is the default.
+// Disabling it asks the API for uncompressed responses.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) error {
+		c.disableCompression = !enabled
+		return nil
+	}
+}
+
+// setAcceptEncoding advertises the supported response encodings
+func (c *Client) setAcceptEncoding(req *http.Request) {
+	if c.disableCompression {
+		req.Header.Set("Accept-Encoding", "identity")
+	} else {
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+}
+
+// decompress replaces the body of a gzip or deflate encoded response with its
+// decompressed content. Other responses are left untouched, as when the
+// server ignored Accept-Encoding, and so are empty bodies.
+func decompress(r *http.Response) error {
+	// Responses without content have nothing to decompress, whatever their
+	// headers
+	if r.StatusCode == http.StatusNoContent || r.StatusCode == http.StatusNotModified ||
+		(r.Request != nil && r.Request.Method == "HEAD") {
+		return nil
+	}
+
+	var newReader func(io.Reader) (io.ReadCloser, error)
+	switch strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		newReader = func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+	case "deflate":
+		newReader = zlib.NewReader
+	default:
+		return nil
+	}
+
+	// An empty body is not a valid gzip or deflate stream
+	body := bufio.NewReader(r.Body)
+	if _, err := body.Peek(1); err == io.EOF {
+		r.Header.Del("Content-Encoding")
+		return nil
+	}
+	reader, err := newReader(body)
+	if err != nil {
+		return err
+	}
+
+	r.Body = &decompressedBody{ReadCloser: reader, body: r.Body}
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+	r.ContentLength = -1
+	r.Uncompressed = true
+	return nil
+}
+
+// decompressedBody reads a decompressed response body, closing the
+// underlying one
+type decompressedBody struct {
+	io.ReadCloser
+	body io.ReadCloser
+}
+
+// Close implements the io.Closer interface
+func (b *decompressedBody) Close() error {
+	b.ReadCloser.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,58 @@
+package ovh
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"testing"
+)
+
+func TestCompressedResponse(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(`{"nichandle":"xx1234-ovh"}`))
+	gz.Close()
+
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	})
+
+	var me struct{ Nichandle string }
+	if err := client.GetInto("/me", &me); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	if me.Nichandle != "xx1234-ovh" {
+		t.Errorf("Nichandle = %q", me.Nichandle)
+	}
+}
+
+// Responses without content are not decompressed, despite their headers
+func TestCompressedResponseWithoutContent(t *testing.T) {
+	for _, encoding := range []string{"gzip", "deflate"} {
+		tests := []struct {
+			method string
+			status int
+		}{
+			{"DELETE", http.StatusNoContent},
+			{"GET", http.StatusNotModified},
+			{"HEAD", http.StatusOK},
+			{"DELETE", http.StatusOK},
+		}
+		for _, tt := range tests {
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", encoding)
+				w.WriteHeader(tt.status)
+			})
+
+			response, err := client.Call(tt.method, "/me/sshKey/key", nil, true)
+			if err != nil {
+				t.Errorf("%s %s %d: %v", encoding, tt.method, tt.status, err)
+				continue
+			}
+			if response.StatusCode != tt.status || len(response.Body) != 0 {
+				t.Errorf("%s %s %d: got %d %q", encoding, tt.method, tt.status, response.StatusCode, response.Body)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ovh
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// timeHandler answers /auth/time with the current time and other paths with
+// an empty object
+func timeHandler(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/auth/time") {
+		fmt.Fprintf(w, "%d", time.Now().Unix())
+		return
+	}
+	w.Write([]byte("{}"))
+}
+
+// Run with -race: requests are signed while the time delta and the consumer
+// key change
+func TestConcurrentRefresh(t *testing.T) {
+	client, _ := newTestClient(t, timeHandler)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if err := client.GetInto("/me", nil); err != nil {
+				t.Errorf("GetInto: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := client.RefreshTimeDelta(); err != nil {
+				t.Errorf("RefreshTimeDelta: %v", err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			client.setTimeDelta(int64(i))
+			client.setConsumerKey(fmt.Sprintf("consumer-key-%d", i))
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Run with -race: concurrent calls share the client and its timeout
+func TestConcurrentCalls(t *testing.T) {
+	client, _ := newTestClient(t, timeHandler, WithTimeout(5*time.Second))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.GetInto("/me", nil); err != nil {
+				t.Errorf("GetInto: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if client.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %s, want 5s", client.Timeout)
+	}
+}
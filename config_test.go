@@ -0,0 +1,132 @@
+package ovh
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewClientUnknownEndpoint(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := NewClient("ovh-moon", testApplicationKey, testApplicationSecret, testConsumerKey)
+	if err == nil || !strings.Contains(err.Error(), `unknown endpoint "ovh-moon"`) {
+		t.Errorf("NewClient error = %v, want unknown endpoint", err)
+	}
+}
+
+func TestNewClientNoEndpoint(t *testing.T) {
+	isolateConfig(t, filepath.Join(t.TempDir(), "missing.conf"))
+
+	_, err := NewClient("", testApplicationKey, testApplicationSecret, testConsumerKey)
+	if !errors.Is(err, ErrNoEnpoint) {
+		t.Errorf("NewClient error = %v, want ErrNoEnpoint", err)
+	}
+}
+
+// writeConfig writes ``content`` to a configuration file in a temporary
+// directory and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ovh.conf")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Explicit values take precedence over the environment, which takes
+// precedence over the configuration files
+func TestNewClientPrecedence(t *testing.T) {
+	config := "[default]\nendpoint=ovh-ca\n"
+	for _, section := range []string{"ovh-eu", "ovh-ca", "ovh-us"} {
+		config += "[" + section + "]\n" +
+			"application_key=config-ak\n" +
+			"application_secret=config-as\n" +
+			"consumer_key=config-ck\n"
+	}
+
+	values := []struct {
+		name                   string
+		env                    string
+		config, fromEnv, given string
+		get                    func(*Client) string
+	}{
+		{"endpoint", EnvEndpoint, "ovh-ca", "ovh-us", "ovh-eu", func(c *Client) string { return c.endpointName }},
+		{"application key", EnvApplicationKey, "config-ak", "env-ak", "explicit-ak", func(c *Client) string { return c.applicationKey }},
+		{"application secret", EnvApplicationSecret, "config-as", "env-as", "explicit-as", func(c *Client) string { return c.applicationSecret }},
+		{"consumer key", EnvConsumerKey, "config-ck", "env-ck", "explicit-ck", func(c *Client) string { return c.consumerKey }},
+	}
+	for i, v := range values {
+		for _, level := range []string{"config", "env", "explicit"} {
+			t.Run(v.name+" from "+level, func(t *testing.T) {
+				isolateConfig(t, writeConfig(t, config))
+
+				want := v.config
+				if level != "config" {
+					t.Setenv(v.env, v.fromEnv)
+					want = v.fromEnv
+				}
+				args := make([]string, 4)
+				if level == "explicit" {
+					args[i] = v.given
+					want = v.given
+				}
+
+				client, err := NewClient(args[0], args[1], args[2], args[3])
+				if err != nil {
+					t.Fatalf("NewClient: %v", err)
+				}
+				if got := v.get(client); got != want {
+					t.Errorf("%s = %q, want %q", v.name, got, want)
+				}
+			})
+		}
+	}
+}
+
+// No configuration file is needed when everything is given explicitly
+func TestNewClientWithoutConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	isolateConfig(t, filepath.Join(dir, "ovh.conf"), "~/missing-ovh.conf", filepath.Join(dir, "missing", "ovh.conf"))
+
+	client, err := NewClient("ovh-eu", testApplicationKey, testApplicationSecret, testConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.endpoint != OvhEU {
+		t.Errorf("endpoint = %q, want %q", client.endpoint, OvhEU)
+	}
+	if client.applicationKey != testApplicationKey || client.applicationSecret != testApplicationSecret || client.consumerKey != testConsumerKey {
+		t.Errorf("credentials = %q, %q, %q", client.applicationKey, client.applicationSecret, client.consumerKey)
+	}
+
+	// Nor when the configuration files are needed but missing
+	client, err = NewClient("ovh-eu", "", "", "")
+	if err != nil {
+		t.Fatalf("NewClient without credentials: %v", err)
+	}
+	if client.applicationKey != "" {
+		t.Errorf("applicationKey = %q, want none", client.applicationKey)
+	}
+}
+
+func TestNewClientMalformedConfig(t *testing.T) {
+	path := writeConfig(t, "[default]\nendpoint=ovh-eu\n[ovh-eu\napplication_key=ak\n")
+	isolateConfig(t, path)
+
+	_, err := NewClient("", "", "", "")
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("NewClient error = %v, want a *ConfigError", err)
+	}
+	if configErr.Path != path {
+		t.Errorf("Path = %q, want %q", configErr.Path, path)
+	}
+	if !strings.Contains(err.Error(), path) {
+		t.Errorf("error %q does not name %s", err, path)
+	}
+}
@@ -37,6 +37,9 @@ type CkRequest struct {
 	endpoint    Endpoint
 	appKey      string
 	AccessRules []*AccessRule `json:"accessRules"`
+	// IP ranges, in CIDR notation, the consumer key will be restricted to.
+	// When empty, the key is usable from anywhere.
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
 }
 
 // String implements the stringer interface
@@ -65,6 +68,12 @@ func (ck *CkRequest) AddRule(method, path string) {
 	})
 }
 
+// AddAllowedIP restricts the requested consumer key to ``ipRange``, in CIDR
+// notation (e.g. 192.0.2.0/24)
+func (ck *CkRequest) AddAllowedIP(ipRange string) {
+	ck.AllowedIPs = append(ck.AllowedIPs, ipRange)
+}
+
 // Do runs the request
 func (ck *CkRequest) Do() (*CkValidationState, error) {
 	params, err := json.Marshal(ck)
@@ -92,7 +101,10 @@ func (ck *CkRequest) Do() (*CkValidationState, error) {
 	}
 
 	if result.StatusCode != http.StatusOK {
-		apiError := &APIError{ErrorCode: result.StatusCode}
+		apiError := &APIError{
+			StatusCode: result.StatusCode,
+			QueryID:    result.Header.Get("X-Ovh-Queryid"),
+		}
 		if err = json.Unmarshal(body, apiError); err != nil {
 			return nil, err
 		}
@@ -107,3 +119,45 @@ func (ck *CkRequest) Do() (*CkValidationState, error) {
 
 	return state, nil
 }
+
+// RequestConsumerKey asks the API for a new consumer key granted with
+// ``accessRules``. The key is only usable once the customer visited the
+// returned ValidationURL: the client keeps its current key until told to
+// switch with UseConsumerKey.
+func (c *Client) RequestConsumerKey(accessRules []AccessRule) (*CkValidationState, error) {
+	return c.RequestRestrictedConsumerKey(accessRules, nil)
+}
+
+// RequestRestrictedConsumerKey is the same as RequestConsumerKey, except the
+// new key is only valid from the ``restrictedIPs`` ranges, in CIDR notation
+func (c *Client) RequestRestrictedConsumerKey(accessRules []AccessRule, restrictedIPs []string) (*CkValidationState, error) {
+	ck := &CkRequest{
+		endpoint:    c.endpoint,
+		appKey:      c.applicationKey,
+		AccessRules: make([]*AccessRule, 0, len(accessRules)),
+	}
+	for i := range accessRules {
+		ck.AddRule(accessRules[i].Method, accessRules[i].Path)
+	}
+	for _, ipRange := range restrictedIPs {
+		ck.AddAllowedIP(ipRange)
+	}
+
+	response, err := c.PostUnAuth("/auth/credential", ck)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &CkValidationState{}
+	if err := response.UnmarshalInto(state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// UseConsumerKey switches the client to ``consumerKey``, e.g. the one of
+// RequestConsumerKey once validated. Calls in flight keep the previous key.
+func (c *Client) UseConsumerKey(consumerKey string) {
+	c.setConsumerKey(consumerKey)
+}
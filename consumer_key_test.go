@@ -0,0 +1,36 @@
+package ovh
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRequestConsumerKeyKeepsCurrentKey(t *testing.T) {
+	var request CkRequest
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"consumerKey":"new-key","state":"pendingValidation","validationUrl":"https://eu.api.ovh.com/auth/?credentialToken=x"}`))
+	})
+
+	state, err := client.RequestRestrictedConsumerKey([]AccessRule{{Method: "GET", Path: "/me"}}, []string{"192.0.2.0/24"})
+	if err != nil {
+		t.Fatalf("RequestRestrictedConsumerKey: %v", err)
+	}
+	if state.ConsumerKey != "new-key" || state.State != "pendingValidation" {
+		t.Errorf("state = %+v", state)
+	}
+	if len(request.AccessRules) != 1 || request.AccessRules[0].Path != "/me" || len(request.AllowedIPs) != 1 {
+		t.Errorf("request = %+v", request)
+	}
+
+	if got := client.getConsumerKey(); got != testConsumerKey {
+		t.Errorf("consumer key = %q, want the pending key not to replace %q", got, testConsumerKey)
+	}
+	client.UseConsumerKey(state.ConsumerKey)
+	if got := client.getConsumerKey(); got != "new-key" {
+		t.Errorf("consumer key after UseConsumerKey = %q, want %q", got, "new-key")
+	}
+}
@@ -0,0 +1,106 @@
+package ovh
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// CredentialSource supplies the configuration of clients, by section and
+// key, as laid out in the INI configuration files: the "default" section
+// names the "endpoint", and the section of the endpoint, or of the profile,
+// holds the "application_key", "application_secret" and "consumer_key".
+// Environment variables take precedence over it.
+type CredentialSource interface {
+	// Value returns the value of ``key`` in ``section``, "" if unset
+	Value(section, key string) string
+}
+
+// WithCredentialSource loads the configuration from ``source`` instead of the
+// INI configuration files
+func WithCredentialSource(source CredentialSource) Option {
+	return func(c *Client) error {
+		c.credentialSource = source
+		return nil
+	}
+}
+
+// iniSource is a CredentialSource backed by INI files
+type iniSource struct {
+	cfg *ini.File
+}
+
+// NewINISource loads the INI files at ``paths`` by order of increasing
+// priority. A leading "~/" stands for the current user home. Missing files
+// are skipped, malformed ones are reported as a *ConfigError.
+func NewINISource(paths ...string) (CredentialSource, error) {
+	// All configuration files are optional. Only load file from user home
+	// if home could be resolve
+	cfg := ini.Empty()
+	for _, path := range paths {
+		if strings.HasPrefix(path, "~/") {
+			home, err := currentUserHome()
+			if err != nil {
+				continue
+			}
+			path = home + path[1:]
+		}
+
+		// A missing file would make every subsequent load fail
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := cfg.Append(path); err != nil {
+			return nil, &ConfigError{Path: path, Err: err}
+		}
+	}
+	return &iniSource{cfg: cfg}, nil
+}
+
+// Value implements the CredentialSource interface
+func (s *iniSource) Value(section, key string) string {
+	fromSection := s.cfg.Section(section)
+	if fromSection == nil {
+		return ""
+	}
+
+	fromSectionKey := fromSection.Key(key)
+	if fromSectionKey == nil {
+		return ""
+	}
+	return fromSectionKey.String()
+}
+
+// MapSource is a CredentialSource held in memory, by section then key
+type MapSource map[string]map[string]string
+
+// Value implements the CredentialSource interface
+func (s MapSource) Value(section, key string) string {
+	return s[section][key]
+}
+
+// NewJSONSource loads the JSON file at ``path``, an object of sections, each
+// an object of string values. e.g. :
+// 		{
+// 			"default": {"endpoint": "ovh-eu"},
+// 			"ovh-eu": {
+// 				"application_key": "...",
+// 				"application_secret": "...",
+// 				"consumer_key": "..."
+// 			}
+// 		}
+func NewJSONSource(path string) (CredentialSource, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+
+	source := MapSource{}
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	return source, nil
+}
@@ -0,0 +1,45 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMissingCredentials(t *testing.T) {
+	tests := []struct {
+		name                                           string
+		applicationKey, applicationSecret, consumerKey string
+		want                                           error
+	}{
+		{"all", "", "", "", ErrMissingApplicationKey},
+		{"application key", "", "secret", "ck", ErrMissingApplicationKey},
+		{"application key and secret", "", "", "ck", ErrMissingApplicationKey},
+		{"application key and consumer key", "", "secret", "", ErrMissingApplicationKey},
+		{"application secret", "ak", "", "ck", ErrMissingApplicationSecret},
+		{"application secret and consumer key", "ak", "", "", ErrMissingApplicationSecret},
+		{"consumer key", "ak", "secret", "", ErrMissingConsumerKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Write([]byte("{}"))
+			}, WithCredentials(tt.applicationKey, tt.applicationSecret, tt.consumerKey))
+
+			err := client.GetInto("/me", nil)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetInto: got %v, want %v", err, tt.want)
+			}
+			if requests != 0 {
+				t.Errorf("%d requests sent, want none", requests)
+			}
+
+			// Unauthenticated calls need no credentials
+			if _, err := client.GetUnAuth("/me"); err != nil {
+				t.Errorf("GetUnAuth: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package ovh
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// DebugFunc receives each request sent by the client along with its response.
+// ``resp`` is nil when the request failed at the transport level. Secrets in
+// the request headers are redacted and both bodies may be read freely, except
+// for the response body of CallStream which is left to the caller.
+type DebugFunc func(req *http.Request, resp *http.Response)
+
+// redactedHeaders lists the request headers never handed to a DebugFunc
+var redactedHeaders = []string{
+	"Authorization",
+	"X-Ovh-Consumer",
+	"X-Ovh-Signature",
+}
+
+// SetDebugFunc registers ``debug`` to be invoked after each request. Pass nil
+// to disable it.
+func (c *Client) SetDebugFunc(debug DebugFunc) {
+	c.lock.Lock()
+	c.debugFunc = debug
+	c.lock.Unlock()
+}
+
+// debug hands a redacted copy of ``req`` and ``resp`` to the DebugFunc, if any
+func (c *Client) debug(req *http.Request, body []byte, resp *http.Response, respBody []byte) {
+	c.lock.RLock()
+	debug := c.debugFunc
+	c.lock.RUnlock()
+
+	if debug == nil {
+		return
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	for _, name := range redactedHeaders {
+		if req.Header.Get(name) != "" {
+			req.Header.Set(name, "REDACTED")
+		}
+	}
+
+	if resp != nil {
+		copied := *resp
+		copied.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		resp = &copied
+	}
+
+	debug(req, resp)
+}
@@ -0,0 +1,111 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InstallStatus represents the progress of a dedicated server installation
+type InstallStatus struct {
+	// Seconds elapsed since the installation started
+	ElapsedTime int64         `json:"elapsedTime"`
+	Progress    []InstallStep `json:"progress"`
+}
+
+// InstallStep represents a step of a dedicated server installation
+type InstallStep struct {
+	// One of "todo", "doing", "done" or "error"
+	Status  string `json:"status"`
+	Comment string `json:"comment"`
+	Error   string `json:"error"`
+}
+
+// Completion returns the number of done steps, out of ``total``
+func (s *InstallStatus) Completion() (done, total int) {
+	for _, step := range s.Progress {
+		if step.Status == "done" {
+			done++
+		}
+	}
+	return done, len(s.Progress)
+}
+
+// failedStep returns the step in error, if any
+func (s *InstallStatus) failedStep() *InstallStep {
+	for i := range s.Progress {
+		if s.Progress[i].Status == "error" {
+			return &s.Progress[i]
+		}
+	}
+	return nil
+}
+
+// DedicatedService wraps the /dedicated/server endpoints
+type DedicatedService struct {
+	client *Client
+}
+
+// Dedicated returns a helper for the /dedicated/server endpoints
+func (c *Client) Dedicated() *DedicatedService {
+	return &DedicatedService{client: c}
+}
+
+// InstallStatus returns the progress of the installation of ``serviceName``.
+// The API answers 404 Not Found, matching ErrNotFound, when no installation
+// is running.
+func (d *DedicatedService) InstallStatus(serviceName string) (*InstallStatus, error) {
+	return d.installStatus(context.Background(), serviceName)
+}
+
+// installStatus is InstallStatus, bound to ``ctx``
+func (d *DedicatedService) installStatus(ctx context.Context, serviceName string) (*InstallStatus, error) {
+	response, err := d.client.GetWithContext(ctx, BuildPath("/dedicated/server/{serviceName}/install/status", serviceName))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &InstallStatus{}
+	if err := response.UnmarshalInto(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// WaitForInstall polls the installation of ``serviceName`` every
+// TaskPollInterval until it is over, that is once the API no longer reports
+// it as running. An error is returned if a step fails, and an error wrapping
+// ErrTaskTimeout if the installation is still running after ``timeout``. A
+// 404 Not Found on the first poll, e.g. for an unknown server or when no
+// installation was started, is returned as is.
+func (d *DedicatedService) WaitForInstall(serviceName string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(TaskPollInterval)
+	defer ticker.Stop()
+
+	for polled := false; ; polled = true {
+		status, err := d.installStatus(ctx, serviceName)
+		if polled && errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w: installation of %s still running after %s", ErrTaskTimeout, serviceName, timeout)
+		}
+		if err != nil {
+			return err
+		}
+
+		if step := status.failedStep(); step != nil {
+			return fmt.Errorf("ovh: installation of %s failed at %q: %s", serviceName, step.Comment, step.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: installation of %s still running after %s", ErrTaskTimeout, serviceName, timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fastPolling shortens TaskPollInterval for the duration of the test
+func fastPolling(t *testing.T) {
+	saved := TaskPollInterval
+	TaskPollInterval = time.Millisecond
+	t.Cleanup(func() { TaskPollInterval = saved })
+}
+
+const notFoundBody = `{"errorCode":"RESOURCE_NOT_FOUND","httpCode":"404 Not Found","message":"The requested object does not exist"}`
+
+func TestWaitForInstall(t *testing.T) {
+	fastPolling(t)
+
+	polls := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dedicated/server/ns1.example.net/install/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		polls++
+		if polls < 3 {
+			w.Write([]byte(`{"elapsedTime":42,"progress":[{"status":"done","comment":"Partitioning"},{"status":"doing","comment":"Installing"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(notFoundBody))
+	})
+
+	if err := client.Dedicated().WaitForInstall("ns1.example.net", 5*time.Second); err != nil {
+		t.Fatalf("WaitForInstall: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("%d polls, want 3", polls)
+	}
+}
+
+// A 404 before any successful poll is not a finished installation
+func TestWaitForInstallNotFound(t *testing.T) {
+	fastPolling(t)
+
+	client, _ := newTestClient(t, respond(http.StatusNotFound, notFoundBody))
+
+	err := client.Dedicated().WaitForInstall("ns1.example.net", 5*time.Second)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("WaitForInstall error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWaitForInstallFailedStep(t *testing.T) {
+	fastPolling(t)
+
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"progress":[{"status":"error","comment":"Installing","error":"disk failure"}]}`))
+
+	err := client.Dedicated().WaitForInstall("ns1.example.net", 5*time.Second)
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("WaitForInstall error = %v, want the failed step", err)
+	}
+}
+
+func TestWaitForInstallTimeout(t *testing.T) {
+	fastPolling(t)
+
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"progress":[{"status":"doing","comment":"Installing"}]}`))
+
+	err := client.Dedicated().WaitForInstall("ns1.example.net", 20*time.Millisecond)
+	if !errors.Is(err, ErrTaskTimeout) {
+		t.Fatalf("WaitForInstall error = %v, want ErrTaskTimeout", err)
+	}
+	if !strings.HasPrefix(err.Error(), "ovh: ") || !strings.Contains(err.Error(), "ns1.example.net") {
+		t.Errorf("error = %q, want an ovh: error naming the server", err)
+	}
+}
@@ -0,0 +1,87 @@
+package ovh
+
+import "strconv"
+
+// DNSRecord represents a record of a DNS zone
+type DNSRecord struct {
+	ID   int64  `json:"id,omitempty"`
+	Zone string `json:"zone,omitempty"`
+	// Record type, e.g. "A", "CNAME" or "TXT"
+	FieldType string `json:"fieldType"`
+	// Sub domain, relative to the zone. Empty for the zone apex
+	SubDomain string `json:"subDomain"`
+	Target    string `json:"target"`
+	// Time to live in seconds. 0 means the zone default
+	TTL int `json:"ttl"`
+}
+
+// DNSService wraps the /domain/zone endpoints
+type DNSService struct {
+	client *Client
+}
+
+// DNS returns a helper for the /domain/zone DNS endpoints
+func (c *Client) DNS() *DNSService {
+	return &DNSService{client: c}
+}
+
+// ListRecords returns the ids of the records of ``zone``
+func (d *DNSService) ListRecords(zone string) ([]int64, error) {
+	ids := []int64{}
+	if err := d.client.GetInto(BuildPath("/domain/zone/{zoneName}/record", zone), &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetRecord returns the record ``id`` of ``zone``
+func (d *DNSService) GetRecord(zone string, id int64) (*DNSRecord, error) {
+	record := &DNSRecord{}
+	if err := d.client.GetInto(BuildPath("/domain/zone/{zoneName}/record/{id}", zone, strconv.FormatInt(id, 10)), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// CreateRecord adds ``record`` to ``zone`` and returns it as created. Call
+// RefreshZone to apply the change.
+func (d *DNSService) CreateRecord(zone string, record DNSRecord) (*DNSRecord, error) {
+	params := map[string]interface{}{
+		"fieldType": record.FieldType,
+		"subDomain": record.SubDomain,
+		"target":    record.Target,
+		"ttl":       record.TTL,
+	}
+
+	created := &DNSRecord{}
+	if err := d.client.PostInto(BuildPath("/domain/zone/{zoneName}/record", zone), params, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateRecord updates the sub domain, target and ttl of the record ``id`` of
+// ``zone``. Call RefreshZone to apply the change.
+func (d *DNSService) UpdateRecord(zone string, id int64, record DNSRecord) error {
+	params := map[string]interface{}{
+		"subDomain": record.SubDomain,
+		"target":    record.Target,
+		"ttl":       record.TTL,
+	}
+	return d.client.PutInto(BuildPath("/domain/zone/{zoneName}/record/{id}", zone, strconv.FormatInt(id, 10)), params, nil)
+}
+
+// DeleteRecord removes the record ``id`` from ``zone``. Call RefreshZone to
+// apply the change.
+func (d *DNSService) DeleteRecord(zone string, id int64) error {
+	response, err := d.client.Delete(BuildPath("/domain/zone/{zoneName}/record/{id}", zone, strconv.FormatInt(id, 10)))
+	if err != nil {
+		return err
+	}
+	return response.UnmarshalInto(nil)
+}
+
+// RefreshZone applies the pending changes of ``zone``
+func (d *DNSService) RefreshZone(zone string) error {
+	return d.client.PostInto(BuildPath("/domain/zone/{zoneName}/refresh", zone), nil, nil)
+}
@@ -0,0 +1,47 @@
+package ovh
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// dryRunMethods lists the methods not sent in dry run mode
+var dryRunMethods = map[string]bool{
+	"POST":   true,
+	"PUT":    true,
+	"DELETE": true,
+	"PATCH":  true,
+}
+
+// WithDryRun enables the dry run mode, to validate the sequence of requests of
+// a workflow before arming it. Authenticated POST, PUT, DELETE and PATCH
+// requests are neither signed nor sent, so they never hit the network, not
+// even /auth/time: they are handed to the DebugFunc, if any, and get an empty
+// 200 OK response. Other requests are sent as usual so that reads work.
+func WithDryRun(enabled bool) Option {
+	return func(c *Client) error {
+		c.dryRun = enabled
+		return nil
+	}
+}
+
+// skipInDryRun reports whether ``req`` must not be sent
+func (c *Client) skipInDryRun(req *http.Request, needAuth bool) bool {
+	return c.dryRun && needAuth && dryRunMethods[req.Method]
+}
+
+// dryRunResponse returns the synthetic response of a request not sent in dry
+// run mode
+func dryRunResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}
+}
@@ -0,0 +1,55 @@
+package ovh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Skipped requests reach neither the API, not even /auth/time, nor the rate
+// limiter
+func TestDryRun(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+		WithDryRun(true),
+		WithRateLimit(1, 2),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		response, err := client.Post("/me/sshKey", map[string]string{"key": "ssh-rsa"})
+		if err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("dry run response status = %d", response.StatusCode)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("dry run requests waited for the rate limiter: %s", elapsed)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("%d requests sent, want none", n)
+	}
+
+	// Reads are sent
+	if err := client.GetInto("/me", nil); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n == 0 {
+		t.Error("GET was not sent")
+	}
+}
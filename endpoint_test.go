@@ -0,0 +1,153 @@
+package ovh
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestRegisterEndpoint(t *testing.T) {
+	t.Cleanup(func() {
+		endpointsLock.Lock()
+		delete(Endpoints, "test-sandbox")
+		endpointsLock.Unlock()
+	})
+
+	if err := RegisterEndpoint("test-sandbox", "not a url", false); err == nil {
+		t.Error("RegisterEndpoint accepted a relative uri")
+	}
+	if IsValidEndpoint("test-sandbox") {
+		t.Error("invalid endpoint was registered")
+	}
+
+	if err := RegisterEndpoint("test-sandbox", "https://sandbox.example.com/1.0/", false); err != nil {
+		t.Fatalf("RegisterEndpoint: %v", err)
+	}
+	if endpoint, _ := lookupEndpoint("test-sandbox"); endpoint != "https://sandbox.example.com/1.0" {
+		t.Errorf("registered endpoint = %q", endpoint)
+	}
+
+	if err := RegisterEndpoint("test-sandbox", "https://other.example.com/1.0", false); !errors.Is(err, ErrEndpointExists) {
+		t.Errorf("RegisterEndpoint over an existing name = %v, want ErrEndpointExists", err)
+	}
+	if err := RegisterEndpoint("test-sandbox", "https://other.example.com/1.0", true); err != nil {
+		t.Errorf("RegisterEndpoint with override: %v", err)
+	}
+}
+
+func TestNewClientEndpointURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     Endpoint // empty for an error
+	}{
+		{"http://localhost:8080/1.0", "http://localhost:8080/1.0"},
+		{"https://api.example.com/1.0/", "https://api.example.com/1.0"},
+		{"api.example.com/1.0", ""},
+		{"/1.0/me", ""},
+		{"ftp://api.example.com/1.0", ""},
+		{"https:///1.0", ""},
+		{"ovh-eu", OvhEU},
+		{"ovh-ca", OvhCA},
+		{"kimsufi-eu", KimsufiEU},
+	}
+	for _, tt := range tests {
+		isolateConfig(t)
+
+		client, err := NewClient(tt.endpoint, testApplicationKey, testApplicationSecret, testConsumerKey)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%q: NewClient accepted an invalid endpoint", tt.endpoint)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: NewClient: %v", tt.endpoint, err)
+			continue
+		}
+		if client.endpoint != tt.want {
+			t.Errorf("%q: endpoint = %q, want %q", tt.endpoint, client.endpoint, tt.want)
+		}
+	}
+}
+
+func TestUSEndpoint(t *testing.T) {
+	isolateConfig(t)
+
+	if !IsValidEndpoint("ovh-us") {
+		t.Error("ovh-us is not a valid endpoint")
+	}
+	client, err := NewClient("ovh-us", testApplicationKey, testApplicationSecret, testConsumerKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.endpoint != OvhUS {
+		t.Errorf("endpoint = %q, want %q", client.endpoint, OvhUS)
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		version  string
+		want     Endpoint
+	}{
+		{"ovh-eu", "v2", "https://eu.api.ovh.com/v2"},
+		{"ovh-us", "/v2/", "https://api.us.ovhcloud.com/v2"},
+		{"ovh-ca", "1.0", OvhCA},
+		// URLs are used as is
+		{"https://api.example.com/1.0", "v2", "https://api.example.com/1.0"},
+		{"http://localhost:8080", "v2", "http://localhost:8080"},
+	}
+	for _, tt := range tests {
+		isolateConfig(t)
+
+		client, err := NewClientWithOptions(
+			WithEndpoint(tt.endpoint),
+			WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+			WithAPIVersion(tt.version),
+		)
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.endpoint, tt.version, err)
+			continue
+		}
+		if client.endpoint != tt.want {
+			t.Errorf("%s %s: endpoint = %q, want %q", tt.endpoint, tt.version, client.endpoint, tt.want)
+		}
+	}
+
+	// Nor with WithBaseURL
+	client, err := NewClientWithOptions(WithBaseURL("https://api.example.com/1.0"), WithAPIVersion("v2"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.endpoint != "https://api.example.com/1.0" {
+		t.Errorf("endpoint = %q, want the base URL", client.endpoint)
+	}
+
+	if _, err := NewClientWithOptions(WithEndpoint("ovh-eu"), WithAPIVersion("/")); err == nil {
+		t.Error("WithAPIVersion accepted an empty version")
+	}
+}
+
+func TestSupportedEndpoints(t *testing.T) {
+	names := SupportedEndpoints()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("SupportedEndpoints is not sorted: %v", names)
+	}
+
+	supported := map[string]bool{}
+	for _, name := range names {
+		if supported[name] {
+			t.Errorf("%s listed twice", name)
+		}
+		supported[name] = true
+		if !IsValidEndpoint(name) {
+			t.Errorf("listed endpoint %s is not valid", name)
+		}
+	}
+	for _, name := range []string{"ovh-eu", "ovh-ca", "ovh-us", "ovhcloud-eu", "ovhcloud-ca", "ovhcloud-us"} {
+		if !supported[name] {
+			t.Errorf("%s is not listed", name)
+		}
+	}
+}
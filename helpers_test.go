@@ -0,0 +1,101 @@
+package ovh
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// Dummy credentials of the test clients
+const (
+	testApplicationKey    = "test-application-key"
+	testApplicationSecret = "test-application-secret"
+	testConsumerKey       = "test-consumer-key"
+)
+
+// newTestClient starts a server answering with ``handler`` and returns a
+// client pointed at it, with dummy credentials and a zero time delta.
+// ``opts`` are applied after the defaults.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) (*Client, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{
+		WithBaseURL(server.URL),
+		WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+		WithTimeDelta(0),
+	}, opts...)
+	client, err := NewClientWithOptions(opts...)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	return client, server
+}
+
+// respond returns a handler answering with ``status`` and ``body``
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+// isolateConfig points ConfigPaths at ``paths`` and clears the OVH_*
+// environment variables for the duration of the test
+func isolateConfig(t *testing.T, paths ...string) {
+	t.Helper()
+
+	saved := ConfigPaths
+	ConfigPaths = paths
+	t.Cleanup(func() { ConfigPaths = saved })
+
+	for _, env := range []string{EnvEndpoint, EnvApplicationKey, EnvApplicationSecret, EnvConsumerKey} {
+		t.Setenv(env, "")
+	}
+}
+
+// recordedRequest is the last request received by a recording server
+type recordedRequest struct {
+	Method string
+	Target string
+	Header http.Header
+	Body   []byte
+}
+
+// newRecordingClient is like newTestClient, with a server answering ``{}``
+// and recording the last request it received
+func newRecordingClient(t *testing.T, opts ...Option) (*Client, *recordedRequest) {
+	t.Helper()
+
+	recorded := &recordedRequest{}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*recorded = recordedRequest{
+			Method: r.Method,
+			Target: "http://" + r.Host + r.URL.RequestURI(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		}
+		w.Write([]byte("{}"))
+	}, opts...)
+	return client, recorded
+}
+
+// checkSignature fails the test if the signature of ``r`` does not cover its
+// method, target and body
+func checkSignature(t *testing.T, client *Client, r *recordedRequest) {
+	t.Helper()
+
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-Ovh-Timestamp"), 10, 64)
+	if err != nil {
+		t.Fatalf("X-Ovh-Timestamp: %v", err)
+	}
+	want := client.Signature(r.Method, r.Target, r.Body, timestamp)
+	if got := r.Header.Get("X-Ovh-Signature"); got != want {
+		t.Errorf("%s %s: X-Ovh-Signature = %q, want %q", r.Method, r.Target, got, want)
+	}
+}
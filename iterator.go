@@ -0,0 +1,93 @@
+package ovh
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// ListIDs returns the ids listed by the collection at /path. Numerical ids
+// are returned in their decimal form.
+func (c *Client) ListIDs(path string) ([]string, error) {
+	items := []json.RawMessage{}
+	if err := c.GetInto(path, &items); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		var id string
+		if err := json.Unmarshal(item, &id); err != nil {
+			// Not a string, keep the raw value e.g. a number
+			id = string(item)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Iterator walks the items of a collection. It is used like sql.Rows:
+//
+//	it, err := client.Iterate("/domain/zone/example.com/record")
+//	if err != nil {
+//		return err
+//	}
+//	for it.Next() {
+//		record := DNSRecord{}
+//		if err := it.Scan(&record); err != nil {
+//			return err
+//		}
+//	}
+//	return it.Err()
+type Iterator struct {
+	client *Client
+	path   string
+	ids    []string
+	index  int
+	err    error
+}
+
+// Iterate lists the ids of the collection at /path and returns an Iterator
+// over its items
+func (c *Client) Iterate(path string) (*Iterator, error) {
+	ids, err := c.ListIDs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Iterator{
+		client: c,
+		path:   strings.TrimSuffix(path, "/"),
+		ids:    ids,
+		index:  -1,
+	}, nil
+}
+
+// Next advances to the next item. It returns false at the end of the
+// collection or after a failed Scan
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.index+1 >= len(it.ids) {
+		return false
+	}
+	it.index++
+	return true
+}
+
+// ID returns the id of the current item
+func (it *Iterator) ID() string {
+	if it.index < 0 || it.index >= len(it.ids) {
+		return ""
+	}
+	return it.ids[it.index]
+}
+
+// Scan fetches the current item from /path/<id> and decodes it into ``out``
+func (it *Iterator) Scan(out interface{}) error {
+	it.err = it.client.GetInto(it.path+"/"+url.PathEscape(it.ID()), out)
+	return it.err
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,79 @@
+package ovh
+
+// AccountInfo represents the account details of /me
+type AccountInfo struct {
+	// Account identifier, e.g. "ab12345-ovh"
+	Nichandle    string `json:"nichandle"`
+	Email        string `json:"email"`
+	FirstName    string `json:"firstname"`
+	Name         string `json:"name"`
+	Organisation string `json:"organisation"`
+	Country      string `json:"country"`
+	Language     string `json:"language"`
+	// Legal status, e.g. "individual" or "corporation"
+	LegalForm string `json:"legalform"`
+	State     string `json:"state"`
+	Currency  struct {
+		Code   string `json:"code"`
+		Symbol string `json:"symbol"`
+	} `json:"currency"`
+}
+
+// SSHKey represents an SSH public key of the account
+type SSHKey struct {
+	KeyName string `json:"keyName"`
+	Key     string `json:"key"`
+	// Whether the key is installed by default on new servers
+	Default bool `json:"default"`
+}
+
+// MeService wraps the /me account endpoints
+type MeService struct {
+	client *Client
+}
+
+// Me returns a helper for the /me account endpoints
+func (c *Client) Me() *MeService {
+	return &MeService{client: c}
+}
+
+// AccountInfo returns the details of the account
+func (m *MeService) AccountInfo() (*AccountInfo, error) {
+	info := &AccountInfo{}
+	if err := m.client.GetInto("/me", info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ListSSHKeys returns the names of the SSH keys of the account
+func (m *MeService) ListSSHKeys() ([]string, error) {
+	return m.client.GetArray("/me/sshKey")
+}
+
+// GetSSHKey returns the SSH key ``name``
+func (m *MeService) GetSSHKey(name string) (*SSHKey, error) {
+	key := &SSHKey{}
+	if err := m.client.GetInto(BuildPath("/me/sshKey/{keyName}", name), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// AddSSHKey adds the public ``key`` to the account, under ``name``
+func (m *MeService) AddSSHKey(name, key string) error {
+	params := map[string]string{
+		"keyName": name,
+		"key":     key,
+	}
+	return m.client.PostInto("/me/sshKey", params, nil)
+}
+
+// DeleteSSHKey removes the SSH key ``name`` from the account
+func (m *MeService) DeleteSSHKey(name string) error {
+	response, err := m.client.Delete(BuildPath("/me/sshKey/{keyName}", name))
+	if err != nil {
+		return err
+	}
+	return response.UnmarshalInto(nil)
+}
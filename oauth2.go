@@ -0,0 +1,164 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauth2TokenURLs maps API endpoints to their OAuth2 token endpoint
+var oauth2TokenURLs = map[Endpoint]string{
+	OvhEU: "https://www.ovh.com/auth/oauth2/token",
+	OvhCA: "https://ca.ovh.com/auth/oauth2/token",
+	OvhUS: "https://us.ovhcloud.com/auth/oauth2/token",
+}
+
+// tokenExpiryMargin renews tokens slightly before they actually expire
+const tokenExpiryMargin = 10 * time.Second
+
+// oauth2Config obtains and caches OAuth2 client credentials tokens
+type oauth2Config struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+
+	lock   sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// oauth2Token represents a token endpoint response
+type oauth2Token struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// WithOAuth2 authenticates requests with a bearer token obtained from the
+// OAuth2 client credentials flow, instead of signing them with the
+// application secret and consumer key. Tokens are renewed automatically.
+func WithOAuth2(clientID, clientSecret string) Option {
+	return func(c *Client) error {
+		if clientID == "" || clientSecret == "" {
+			return fmt.Errorf("ovh: OAuth2 client id and secret are required")
+		}
+		c.oauth2 = &oauth2Config{
+			clientID:     clientID,
+			clientSecret: clientSecret,
+		}
+		return nil
+	}
+}
+
+// WithOAuth2TokenURL fetches the OAuth2 tokens from ``tokenURL`` rather than
+// from the token endpoint of the API endpoint, which is only known for OvhEU,
+// OvhCA and OvhUS. It is needed with WithOAuth2 on any other endpoint, e.g. a
+// registered or test one.
+func WithOAuth2TokenURL(tokenURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(tokenURL)
+		if err != nil {
+			return fmt.Errorf("ovh: invalid OAuth2 token URL %q: %v", tokenURL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("ovh: invalid OAuth2 token URL %q: not an absolute http(s) URL", tokenURL)
+		}
+		c.oauth2TokenURL = tokenURL
+		return nil
+	}
+}
+
+// resolveTokenURL selects ``override``, if any, or else the token endpoint
+// matching the API ``endpoint``
+func (o *oauth2Config) resolveTokenURL(endpoint Endpoint, override string) error {
+	if override != "" {
+		o.tokenURL = override
+		return nil
+	}
+	tokenURL, ok := oauth2TokenURLs[endpoint]
+	if !ok {
+		return fmt.Errorf("ovh: OAuth2 token URL of endpoint %q unknown, set it with WithOAuth2TokenURL", string(endpoint))
+	}
+	o.tokenURL = tokenURL
+	return nil
+}
+
+// getToken returns a valid access token, fetching a new one when needed
+func (o *oauth2Config) getToken(ctx context.Context, client *http.Client) (string, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.token != "" && time.Now().Before(o.expiry) {
+		return o.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {o.clientID},
+		"client_secret": {o.clientSecret},
+		"scope":         {"all"},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	result, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer result.Body.Close()
+
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		return "", err
+	}
+
+	token := &oauth2Token{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return "", fmt.Errorf("ovh: invalid OAuth2 token response (%s): %v", result.Status, err)
+	}
+	if result.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return "", fmt.Errorf("ovh: failed to obtain OAuth2 token (%s): %s %s", result.Status, token.Error, token.ErrorDescription)
+	}
+
+	o.token = token.AccessToken
+	o.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return o.token, nil
+}
+
+// invalidate drops ``token`` once rejected by the API, so that the next call
+// fetches a new one. A token renewed in the meantime is kept.
+func (o *oauth2Config) invalidate(token string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.token == token {
+		o.token = ""
+	}
+}
+
+// invalidateOAuth2Token drops the OAuth2 token of a call which got a 401
+// Unauthorized response. It reports whether the call is worth retrying with a
+// new token.
+func (c *Client) invalidateOAuth2Token(needAuth bool, response *APIResponse) bool {
+	if !needAuth || c.oauth2 == nil || response == nil || response.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	if response.response == nil || response.response.Request == nil {
+		return false
+	}
+
+	authorization := response.response.Request.Header.Get("Authorization")
+	c.oauth2.invalidate(strings.TrimPrefix(authorization, "Bearer "))
+	return true
+}
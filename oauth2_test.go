@@ -0,0 +1,244 @@
+package ovh
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer is a fake OAuth2 token endpoint, issuing "token-1", "token-2"...
+type tokenServer struct {
+	*httptest.Server
+	fetches int32
+}
+
+// newTokenServer starts a token endpoint issuing tokens valid for
+// ``expiresIn`` seconds, after ``delay``
+func newTokenServer(t *testing.T, expiresIn int, delay time.Duration) *tokenServer {
+	t.Helper()
+
+	ts := &tokenServer{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.FormValue("grant_type") != "client_credentials" ||
+			r.FormValue("client_id") != "client-id" || r.FormValue("client_secret") != "client-secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+		time.Sleep(delay)
+		n := atomic.AddInt32(&ts.fetches, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// Fetches returns the number of tokens issued
+func (ts *tokenServer) Fetches() int {
+	return int(atomic.LoadInt32(&ts.fetches))
+}
+
+// newOAuth2Client returns a test client authenticating with tokens of ``ts``
+func newOAuth2Client(t *testing.T, ts *tokenServer, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	client, _ := newTestClient(t, handler,
+		WithCredentials("", "", ""),
+		WithOAuth2("client-id", "client-secret"),
+		WithOAuth2TokenURL(ts.URL),
+	)
+	return client
+}
+
+// authHandler answers {} and records the authentication headers it got
+type authHandler struct {
+	lock           sync.Mutex
+	authorizations []string
+	signed         bool
+}
+
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.lock.Lock()
+	h.authorizations = append(h.authorizations, r.Header.Get("Authorization"))
+	if r.Header.Get("X-Ovh-Signature") != "" || r.Header.Get("X-Ovh-Timestamp") != "" || strings.HasSuffix(r.URL.Path, "/auth/time") {
+		h.signed = true
+	}
+	h.lock.Unlock()
+	w.Write([]byte("{}"))
+}
+
+func TestOAuth2(t *testing.T) {
+	ts := newTokenServer(t, 3600, 0)
+	api := &authHandler{}
+	client := newOAuth2Client(t, ts, api.ServeHTTP)
+
+	for i := 0; i < 3; i++ {
+		if err := client.GetInto("/me", nil); err != nil {
+			t.Fatalf("GetInto: %v", err)
+		}
+	}
+
+	if ts.Fetches() != 1 {
+		t.Errorf("%d tokens fetched, want 1", ts.Fetches())
+	}
+	for _, authorization := range api.authorizations {
+		if authorization != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want Bearer token-1", authorization)
+		}
+	}
+	if api.signed {
+		t.Error("OAuth2 requests were signed")
+	}
+}
+
+// Expired tokens are renewed
+func TestOAuth2Expiry(t *testing.T) {
+	// Tokens expire within the renewal margin, i.e. right away
+	ts := newTokenServer(t, int(tokenExpiryMargin/time.Second), 0)
+	api := &authHandler{}
+	client := newOAuth2Client(t, ts, api.ServeHTTP)
+
+	for i := 0; i < 2; i++ {
+		if err := client.GetInto("/me", nil); err != nil {
+			t.Fatalf("GetInto: %v", err)
+		}
+	}
+
+	if ts.Fetches() != 2 {
+		t.Errorf("%d tokens fetched, want 2", ts.Fetches())
+	}
+	if want := []string{"Bearer token-1", "Bearer token-2"}; strings.Join(api.authorizations, ",") != strings.Join(want, ",") {
+		t.Errorf("Authorization headers = %q, want %q", api.authorizations, want)
+	}
+}
+
+// Run with -race: concurrent callers wait for a single token fetch
+func TestOAuth2Concurrent(t *testing.T) {
+	ts := newTokenServer(t, 3600, 50*time.Millisecond)
+	api := &authHandler{}
+	client := newOAuth2Client(t, ts, api.ServeHTTP)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.GetInto("/me", nil); err != nil {
+				t.Errorf("GetInto: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ts.Fetches() != 1 {
+		t.Errorf("%d tokens fetched, want 1", ts.Fetches())
+	}
+}
+
+func TestOAuth2TokenError(t *testing.T) {
+	ts := newTokenServer(t, 3600, 0)
+	client, _ := newTestClient(t, respond(http.StatusOK, "{}"),
+		WithOAuth2("client-id", "wrong-secret"),
+		WithOAuth2TokenURL(ts.URL),
+	)
+
+	err := client.GetInto("/me", nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid_request") {
+		t.Errorf("GetInto error = %v, want the token endpoint error", err)
+	}
+}
+
+func TestOAuth2TokenURL(t *testing.T) {
+	isolateConfig(t)
+
+	// Only the token URL of the OVH endpoints is known
+	if _, err := NewClientWithOptions(WithBaseURL("https://api.example.com/1.0"), WithOAuth2("client-id", "client-secret")); err == nil {
+		t.Error("NewClientWithOptions accepted OAuth2 without a token URL")
+	}
+
+	client, err := NewClientWithOptions(WithEndpoint("ovh-eu"), WithOAuth2("client-id", "client-secret"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.oauth2.tokenURL != oauth2TokenURLs[OvhEU] {
+		t.Errorf("token URL = %q", client.oauth2.tokenURL)
+	}
+
+	for _, tokenURL := range []string{"", "/auth/oauth2/token", "ftp://example.com/token"} {
+		if _, err := NewClientWithOptions(WithBaseURL("https://api.example.com/1.0"), WithOAuth2TokenURL(tokenURL)); err == nil {
+			t.Errorf("WithOAuth2TokenURL(%q) accepted an invalid URL", tokenURL)
+		}
+	}
+}
+
+// A token rejected with a 401 is renewed once, and the call sent again
+func TestOAuth2RenewOnUnauthorized(t *testing.T) {
+	ts := newTokenServer(t, 3600, 0)
+	var authorizations []string
+	client := newOAuth2Client(t, ts, func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Invalid token"}`))
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", response.StatusCode)
+	}
+	if ts.Fetches() != 2 {
+		t.Errorf("%d tokens fetched, want 2", ts.Fetches())
+	}
+	if want := "Bearer token-1,Bearer token-2"; strings.Join(authorizations, ",") != want {
+		t.Errorf("Authorization headers = %q, want %s", authorizations, want)
+	}
+}
+
+// A new token rejected as well is reported rather than renewed again
+func TestOAuth2UnauthorizedTwice(t *testing.T) {
+	ts := newTokenServer(t, 3600, 0)
+	requests := 0
+	client := newOAuth2Client(t, ts, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Invalid token"}`))
+	})
+
+	err := client.GetInto("/me", nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetInto error = %v, want ErrUnauthorized", err)
+	}
+	if requests != 2 || ts.Fetches() != 2 {
+		t.Errorf("%d requests and %d tokens fetched, want 2 of each", requests, ts.Fetches())
+	}
+}
+
+// Without OAuth2, a 401 is reported as is
+func TestUnauthorizedConsumerKey(t *testing.T) {
+	requests := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errorCode":"INVALID_CREDENTIAL","httpCode":"401 Unauthorized","message":"This credential does not exist"}`))
+	})
+
+	err := client.GetInto("/me", nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetInto error = %v, want ErrUnauthorized", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,28 @@
+package ovh
+
+import (
+	"time"
+)
+
+// Observer is notified of the outcome of every Call, for instance to export
+// metrics. ``status`` is 0 when no response was received, in which case
+// ``err`` is set. Implementations must be safe for concurrent use.
+type Observer interface {
+	ObserveRequest(method, path string, status int, duration time.Duration, err error)
+}
+
+// WithObserver registers ``observer`` to be notified of every Call
+func WithObserver(observer Observer) Option {
+	return func(c *Client) error {
+		c.observer = observer
+		return nil
+	}
+}
+
+// observe notifies the Observer, if any, of a call started at ``start``
+func (c *Client) observe(method, path string, start time.Time, status int, err error) {
+	if c.observer == nil {
+		return
+	}
+	c.observer.ObserveRequest(method, path, status, time.Since(start), err)
+}
@@ -0,0 +1,161 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client built with NewClientWithOptions
+type Option func(*Client) error
+
+// WithEndpoint selects the API endpoint, either by name (see Endpoints) or
+// by URL
+func WithEndpoint(endpointName string) Option {
+	return func(c *Client) error {
+		c.endpointName = endpointName
+		return nil
+	}
+}
+
+// WithCredentials sets the application key, application secret and consumer
+// key. Empty values are loaded from the external configuration.
+func WithCredentials(applicationKey, applicationSecret, consumerKey string) Option {
+	return func(c *Client) error {
+		c.applicationKey = applicationKey
+		c.applicationSecret = applicationSecret
+		c.consumerKey = consumerKey
+		return nil
+	}
+}
+
+// WithHTTPClient makes the client issue requests with ``httpClient``
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("ovh: nil http client")
+		}
+		c.client = httpClient
+		c.transport = nil
+		return nil
+	}
+}
+
+// WithAPIVersion targets the ``version`` of the API, e.g. "v2", rather than
+// the "1.0" of the named endpoints. It has no effect on endpoints given as a
+// URL, which are used as is.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) error {
+		version = strings.Trim(version, "/")
+		if version == "" {
+			return errors.New("ovh: empty API version")
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// WithContentType sets the Content-Type of request bodies,
+// DefaultContentType by default, e.g. "application/json" for servers
+// rejecting the charset parameter
+func WithContentType(contentType string) Option {
+	return func(c *Client) error {
+		c.contentType = contentType
+		return nil
+	}
+}
+
+// WithMaxResponseBytes bounds the size of the responses buffered in memory,
+// DefaultMaxResponseBytes by default. Larger responses fail with
+// ErrResponseTooLarge, use CallStream to read them. A ``limit`` of 0 disables
+// the check.
+func WithMaxResponseBytes(limit int64) Option {
+	return func(c *Client) error {
+		c.maxResponseBytes = limit
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of each API request, retries excluded. A zero
+// ``timeout`` disables it, leaving only the deadline of the call context.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.Timeout = timeout
+		return nil
+	}
+}
+
+// WithConfigFile loads the configuration from ``path`` instead of the default
+// locations. It may be given multiple times, later files take precedence.
+func WithConfigFile(path string) Option {
+	return func(c *Client) error {
+		c.configFiles = append(c.configFiles, path)
+		return nil
+	}
+}
+
+// WithSignatureAlgorithm selects the hash used to sign authenticated requests.
+// SignatureSHA1 is used by default.
+func WithSignatureAlgorithm(algorithm SignatureAlgorithm) Option {
+	return func(c *Client) error {
+		if _, err := algorithm.newHash(); err != nil {
+			return err
+		}
+		c.signatureAlgorithm = algorithm
+		return nil
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with each request,
+// DefaultUserAgent by default
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithTimeDelta sets the difference, in seconds, between the local clock and
+// the API server clock. This skips the /auth/time round-trip otherwise made
+// before the first authenticated request.
+func WithTimeDelta(timeDelta int64) Option {
+	return func(c *Client) error {
+		c.timeDelta = timeDelta
+		c.timeDeltaDone = true
+		return nil
+	}
+}
+
+// WithProfile loads the credentials from the ``profile`` section of the
+// configuration files instead of the section named after the endpoint. This
+// allows holding multiple credentials for the same endpoint:
+//
+//	[default]
+//	endpoint=ovh-eu
+//
+//	[customer-a]
+//	application_key=...
+//	application_secret=...
+//	consumer_key=...
+//
+// The profile section may also set its own ``endpoint``.
+func WithProfile(profile string) Option {
+	return func(c *Client) error {
+		c.profile = profile
+		return nil
+	}
+}
+
+// WithBaseURL points the client at ``baseURL``, e.g. a test server or a private
+// deployment, instead of a named endpoint. It must be an absolute http(s) URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		endpoint, err := parseEndpointURL(baseURL)
+		if err != nil {
+			return err
+		}
+		c.baseURL = endpoint
+		return nil
+	}
+}
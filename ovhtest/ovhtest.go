@@ -0,0 +1,43 @@
+// Package ovhtest provides helpers to test code relying on the OVH API client
+// against canned responses, without network access.
+package ovhtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	ovh "github.com/yadutaf/go-ovh"
+)
+
+// Dummy credentials the test client is configured with
+const (
+	ApplicationKey    = "test-application-key"
+	ApplicationSecret = "test-application-secret"
+	ConsumerKey       = "test-consumer-key"
+)
+
+// NewTestClient starts an in-memory API server dispatching requests to
+// ``handler`` and returns a client pointed at it, along with a func to stop
+// the server. The client uses dummy credentials and a zero time delta.
+// /auth/time is answered by the server with the current time.
+func NewTestClient(handler http.Handler) (*ovh.Client, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/time", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%d", time.Now().Unix())
+	})
+	mux.Handle("/", handler)
+
+	server := httptest.NewServer(mux)
+	client, err := ovh.NewClientWithOptions(
+		ovh.WithBaseURL(server.URL),
+		ovh.WithCredentials(ApplicationKey, ApplicationSecret, ConsumerKey),
+		ovh.WithTimeDelta(0),
+	)
+	if err != nil {
+		server.Close()
+		panic(fmt.Sprintf("ovhtest: failed to create client: %v", err))
+	}
+	return client, server.Close
+}
@@ -0,0 +1,31 @@
+package ovhtest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewTestClient(t *testing.T) {
+	var consumer string
+	client, cleanup := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		consumer = r.Header.Get("X-Ovh-Consumer")
+		w.Write([]byte(`{"nichandle":"xx1234-ovh"}`))
+	}))
+	defer cleanup()
+
+	var me struct{ Nichandle string }
+	if err := client.GetInto("/me", &me); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	if me.Nichandle != "xx1234-ovh" {
+		t.Errorf("Nichandle = %q", me.Nichandle)
+	}
+	if consumer != ConsumerKey {
+		t.Errorf("X-Ovh-Consumer = %q, want %q", consumer, ConsumerKey)
+	}
+
+	serverTime, err := client.AuthTime()
+	if err != nil || serverTime == 0 {
+		t.Errorf("AuthTime = %d, %v", serverTime, err)
+	}
+}
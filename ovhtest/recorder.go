@@ -0,0 +1,232 @@
+package ovhtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode selects whether a Recorder records or replays interactions
+type Mode int
+
+// Available recorder modes
+const (
+	// ModeRecord sends requests to the API and records the interactions
+	ModeRecord Mode = iota
+	// ModeReplay answers requests with the recorded interactions, without
+	// network access
+	ModeReplay
+)
+
+// Interaction is a recorded request along with its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request. Its authentication headers are not
+// kept, as they change with each run and would leak secrets.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// Path and query, relative to the host. e.g. "/1.0/me"
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Request headers left out of cassettes
+var ignoredHeaders = []string{
+	"Authorization",
+	"X-Ovh-Application",
+	"X-Ovh-Consumer",
+	"X-Ovh-Signature",
+	"X-Ovh-Timestamp",
+	"Accept-Encoding",
+	"User-Agent",
+}
+
+// Recorder is an http.RoundTripper recording API interactions to a cassette
+// file, or replaying them from it, for deterministic tests. Pass
+// Recorder.Client to ovh.WithHTTPClient.
+//
+// Requests are matched on their method, path, query and body, leaving out the
+// OVH signature headers, so that a cassette matches whatever the credentials
+// and the time of the run. Each interaction is replayed once, in order. When
+// replaying, /auth/time is answered with the current time.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	lock         sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a Recorder backed by the cassette file at ``path``. In
+// ModeRecord, requests go through ``next``, http.DefaultTransport if nil, and
+// Save writes the cassette. In ModeReplay, the cassette is loaded at once.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, next: next}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("ovhtest: invalid cassette %s: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// Client returns an http.Client sending its requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes the recorded interactions to the cassette file
+func (r *Recorder) Save() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+// record sends ``req`` and records the interaction
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	// Ask for an uncompressed response, to record it readable
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "identity")
+	if req.Body != nil {
+		req.Body = ioutil.NopCloser(strings.NewReader(recorded.Body))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.lock.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       string(body),
+		},
+	})
+	r.lock.Unlock()
+	return resp, nil
+}
+
+// replay answers ``req`` with the first matching interaction not replayed yet
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/auth/time") {
+		return newResponse(req, http.StatusOK, nil, strconv.FormatInt(time.Now().Unix(), 10)), nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.replayed[i] = true
+
+		response := interaction.Response
+		return newResponse(req, response.StatusCode, response.Header, response.Body), nil
+	}
+	return nil, fmt.Errorf("ovhtest: no recorded interaction left for %s %s", recorded.Method, recorded.URI)
+}
+
+// matches reports whether ``other`` is the same request
+func (rr *RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method && rr.URI == other.URI && rr.Body == other.Body
+}
+
+// recordRequest returns the recorded form of ``req``, reading its body
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+		Header: req.Header.Clone(),
+	}
+	for _, name := range ignoredHeaders {
+		recorded.Header.Del(name)
+	}
+	if len(recorded.Header) == 0 {
+		recorded.Header = nil
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return recorded, err
+		}
+		recorded.Body = string(body)
+	}
+	return recorded, nil
+}
+
+// newResponse builds a response to ``req``
+func newResponse(req *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,112 @@
+package ovhtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	ovh "github.com/yadutaf/go-ovh"
+)
+
+// newRecorderClient returns a client sending its requests to ``baseURL``
+// through ``recorder``
+func newRecorderClient(t *testing.T, baseURL string, recorder *Recorder, consumerKey string) *ovh.Client {
+	t.Helper()
+
+	client, err := ovh.NewClientWithOptions(
+		ovh.WithBaseURL(baseURL),
+		ovh.WithCredentials(ApplicationKey, ApplicationSecret, consumerKey),
+		ovh.WithHTTPClient(recorder.Client()),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	return client
+}
+
+// runScenario makes the calls of the recorded scenario
+func runScenario(t *testing.T, client *ovh.Client) {
+	t.Helper()
+
+	var me struct{ Nichandle string }
+	if err := client.GetInto("/me", &me); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+	if me.Nichandle != "xx1234-ovh" {
+		t.Errorf("Nichandle = %q", me.Nichandle)
+	}
+
+	var key struct{ KeyName string }
+	if err := client.PostInto("/me/sshKey", map[string]string{"keyName": "laptop"}, &key); err != nil {
+		t.Fatalf("PostInto: %v", err)
+	}
+	if key.KeyName != "laptop" {
+		t.Errorf("KeyName = %q", key.KeyName)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/time", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("1700000000"))
+	})
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"nichandle":"xx1234-ovh"}`))
+	})
+	mux.HandleFunc("/me/sshKey", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"keyName":"laptop"}`))
+	})
+	server := httptest.NewServer(mux)
+
+	recorder, err := NewRecorder(cassette, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	runScenario(t, newRecorderClient(t, server.URL, recorder, ConsumerKey))
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Replay without the server, with other credentials and at another time
+	server.Close()
+	replayer, err := NewRecorder(cassette, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	client := newRecorderClient(t, server.URL, replayer, "other-consumer-key")
+	runScenario(t, client)
+
+	// Each interaction is replayed once
+	if err := client.GetInto("/me", nil); err == nil {
+		t.Error("an interaction was replayed twice")
+	}
+}
+
+func TestRecorderMismatch(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(cassette, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	replayer, err := NewRecorder(cassette, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	client := newRecorderClient(t, "http://api.ovh.invalid/1.0", replayer, ConsumerKey)
+	if err := client.GetInto("/me", nil); err == nil {
+		t.Error("GetInto succeeded without a recorded interaction")
+	}
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil {
+		t.Error("NewRecorder replayed a missing cassette")
+	}
+}
@@ -0,0 +1,36 @@
+package ovh
+
+import (
+	"net/url"
+	"strings"
+)
+
+// BuildPath substitutes the ``{placeholders}`` of ``template``, in order, with
+// ``args`` escaped as path segments. For instance:
+//
+//	BuildPath("/email/domain/{domain}/account/{account}", "example.com", "john/doe")
+//
+// returns "/email/domain/example.com/account/john%2Fdoe". Placeholders in
+// excess of ``args`` are left untouched, extra ``args`` are ignored.
+func BuildPath(template string, args ...string) string {
+	var path strings.Builder
+
+	for len(template) > 0 {
+		start := strings.IndexByte(template, '{')
+		if start < 0 || len(args) == 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+
+		path.WriteString(template[:start])
+		path.WriteString(url.PathEscape(args[0]))
+		template = template[start+end+1:]
+		args = args[1:]
+	}
+
+	path.WriteString(template)
+	return path.String()
+}
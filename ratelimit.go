@@ -0,0 +1,127 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket, refilled at a constant rate
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// WithRateLimit limits the client to ``rps`` requests per second, with bursts
+// of up to ``burst`` requests. Requests over the limit wait for their turn,
+// unless their context is done first.
+func WithRateLimit(rps int, burst int) Option {
+	return func(c *Client) error {
+		if rps <= 0 || burst <= 0 {
+			return errors.New("ovh: rate limit and burst must be positive")
+		}
+		c.rateLimiter = &rateLimiter{
+			interval: time.Second / time.Duration(rps),
+			burst:    float64(burst),
+			tokens:   float64(burst),
+			last:     time.Now(),
+		}
+		return nil
+	}
+}
+
+// wait blocks until a request may be sent or ``ctx`` is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.lock.Lock()
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// Reserve a token, possibly ahead of time
+	l.tokens--
+	delay := time.Duration(0)
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.lock.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back
+		l.lock.Lock()
+		l.tokens++
+		l.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+// RateLimitStatus represents the quota headers returned by the API, if any
+type RateLimitStatus struct {
+	// Number of requests allowed in the current window, -1 if unknown
+	Limit int
+	// Number of requests left in the current window, -1 if unknown
+	Remaining int
+	// When the current window ends, zero if unknown
+	Reset time.Time
+}
+
+// rateLimitHeaderPrefixes lists the prefixes of quota headers, by precedence
+var rateLimitHeaderPrefixes = []string{"X-Ratelimit-", "X-Ovh-Ratelimit-"}
+
+// parseRateLimit decodes the quota headers of a response. It returns nil when
+// there is none.
+func parseRateLimit(header http.Header) *RateLimitStatus {
+	for _, prefix := range rateLimitHeaderPrefixes {
+		limit, hasLimit := parseIntHeader(header, prefix+"Limit")
+		remaining, hasRemaining := parseIntHeader(header, prefix+"Remaining")
+		reset, hasReset := parseIntHeader(header, prefix+"Reset")
+		if !hasLimit && !hasRemaining && !hasReset {
+			continue
+		}
+
+		status := &RateLimitStatus{Limit: -1, Remaining: -1}
+		if hasLimit {
+			status.Limit = limit
+		}
+		if hasRemaining {
+			status.Remaining = remaining
+		}
+		if hasReset {
+			// Reset is either a unix timestamp or a number of seconds
+			if reset > 1000000000 {
+				status.Reset = time.Unix(int64(reset), 0)
+			} else {
+				status.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+			}
+		}
+		return status
+	}
+	return nil
+}
+
+// parseIntHeader decodes the integer header ``name``, if present
+func parseIntHeader(header http.Header, name string) (int, bool) {
+	value, err := strconv.Atoi(header.Get(name))
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,142 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls how transient failures are retried. The zero value
+// disables retries.
+type RetryConfig struct {
+	// Total number of attempts, including the first one. 0 or 1 disables retries
+	MaxAttempts int
+	// Delay before the first retry. It doubles on each subsequent retry
+	BaseDelay time.Duration
+	// Upper bound for the delay between 2 attempts. 0 means no bound
+	MaxDelay time.Duration
+	// Fraction of each delay, between 0 and 1, to randomly shave off so that
+	// concurrent clients do not retry in lockstep
+	Jitter float64
+	// Retry non-idempotent methods (POST, PATCH) too. Only enable it when the
+	// called endpoints are known to be safe to replay. Calls made with an
+	// IdempotencyKey are retried regardless
+	RetryNonIdempotent bool
+}
+
+// retryableStatus lists the HTTP codes considered transient
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// isIdempotent reports whether replaying ``method`` is safe
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether another attempt should be made after ``attempt``
+// returned ``response`` and ``err``. ``idempotent`` tells whether the call is
+// safe to replay.
+func (rc *RetryConfig) shouldRetry(ctx context.Context, idempotent bool, attempt int, response *APIResponse, err error) bool {
+	if attempt >= rc.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !rc.RetryNonIdempotent && !idempotent {
+		return false
+	}
+	if err != nil {
+		// Only failures to get a response may be transient: an oversized
+		// response or an OAuth2 token refusal would fail the same way again
+		var transportErr *TransportError
+		return errors.As(err, &transportErr)
+	}
+	if response.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if apiError, _ := response.DecodeError(nil); apiError != nil {
+		return apiError.IsRetryable()
+	}
+	return retryableStatus[response.StatusCode]
+}
+
+// IsRetryable reports whether the failed request may succeed if sent again,
+// either because its HTTP status is transient or because the API reported a
+// QUERY_TIME_OUT
+func (e *APIError) IsRetryable() bool {
+	return e.ErrorCode == "QUERY_TIME_OUT" || retryableStatus[e.StatusCode]
+}
+
+// wait sleeps before the attempt following ``attempt``. It returns false,
+// without waiting, when the context would expire before the delay elapses
+// and returns false as soon as the context is done.
+func (rc *RetryConfig) wait(ctx context.Context, attempt int, response *APIResponse) bool {
+	delay := rc.delay(attempt, response)
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	return sleep(ctx, delay)
+}
+
+// sleep waits for ``delay``, unless ``ctx`` is done first. It reports whether
+// the delay elapsed. Tests replace it to skip the actual waits.
+var sleep = func(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// delay computes the backoff after ``attempt``, honoring the server provided
+// Retry-After header when present
+func (rc *RetryConfig) delay(attempt int, response *APIResponse) time.Duration {
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return retryAfter
+		}
+	}
+
+	delay := rc.BaseDelay << uint(attempt-1)
+	if delay < 0 || (rc.MaxDelay > 0 && delay > rc.MaxDelay) {
+		delay = rc.MaxDelay
+	}
+	if rc.Jitter > 0 {
+		delay -= time.Duration(rc.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// parseRetryAfter decodes a Retry-After header, either in seconds or as an
+// HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldRetryErrors(t *testing.T) {
+	rc := &RetryConfig{MaxAttempts: 3}
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&TransportError{Err: errors.New("connection reset by peer")}, true},
+		{ErrResponseTooLarge, false},
+		{errors.New("ovh: failed to obtain OAuth2 token (400 Bad Request): invalid_client"), false},
+	}
+	for _, tt := range tests {
+		if got := rc.shouldRetry(context.Background(), true, 1, nil, tt.err); got != tt.want {
+			t.Errorf("shouldRetry(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// recordSleeps makes the retry waits instant, and returns the delays asked for
+func recordSleeps(t *testing.T) *[]time.Duration {
+	delays := &[]time.Duration{}
+	saved := sleep
+	sleep = func(ctx context.Context, delay time.Duration) bool {
+		*delays = append(*delays, delay)
+		return true
+	}
+	t.Cleanup(func() { sleep = saved })
+	return delays
+}
+
+// failingHandler answers ``status`` to the first ``failures`` requests, and
+// {} afterwards. ``requests`` counts them.
+func failingHandler(failures int, status int, header http.Header, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if *requests <= failures {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("{}"))
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	delays := recordSleeps(t)
+	requests := 0
+	client, _ := newTestClient(t, failingHandler(3, http.StatusServiceUnavailable, nil, &requests))
+	client.Retry = RetryConfig{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Attempts != 4 || requests != 4 {
+		t.Errorf("status %d after %d attempts, %d requests, want 200 after 4", response.StatusCode, response.Attempts, requests)
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	if !reflect.DeepEqual(*delays, want) {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	delays := recordSleeps(t)
+	requests := 0
+	client, _ := newTestClient(t, failingHandler(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"7"}}, &requests))
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Attempts != 2 {
+		t.Errorf("status %d after %d attempts, want 200 after 2", response.StatusCode, response.Attempts)
+	}
+	if want := []time.Duration{7 * time.Second}; !reflect.DeepEqual(*delays, want) {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	delays := recordSleeps(t)
+	requests := 0
+	client, _ := newTestClient(t, failingHandler(100, http.StatusBadGateway, nil, &requests))
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	response, err := client.Get("/me")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if response.StatusCode != http.StatusBadGateway || response.Attempts != 3 || requests != 3 {
+		t.Errorf("status %d after %d attempts, %d requests, want 502 after 3", response.StatusCode, response.Attempts, requests)
+	}
+	if len(*delays) != 2 {
+		t.Errorf("%d waits, want 2", len(*delays))
+	}
+}
+
+// POST is only replayed when marked idempotent
+func TestRetryNonIdempotent(t *testing.T) {
+	recordSleeps(t)
+	requests := 0
+	client, _ := newTestClient(t, failingHandler(1, http.StatusServiceUnavailable, nil, &requests))
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	response, err := client.Post("/me/sshKey", nil)
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if response.StatusCode != http.StatusServiceUnavailable || response.Attempts != 1 || requests != 1 {
+		t.Errorf("status %d after %d attempts, %d requests, want 503 after 1", response.StatusCode, response.Attempts, requests)
+	}
+
+	requests = 0
+	response, err = client.PostWithOptions("/me/sshKey", nil, IdempotencyKey("key-1"))
+	if err != nil {
+		t.Fatalf("PostWithOptions: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Attempts != 2 || requests != 2 {
+		t.Errorf("status %d after %d attempts, %d requests, want 200 after 2", response.StatusCode, response.Attempts, requests)
+	}
+
+	requests = 0
+	client.Retry.RetryNonIdempotent = true
+	response, err = client.Post("/me/sshKey", nil)
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Attempts != 2 {
+		t.Errorf("status %d after %d attempts, want 200 after 2", response.StatusCode, response.Attempts)
+	}
+}
+
+// No retry is attempted when the context would expire during the backoff
+func TestRetryContextDeadline(t *testing.T) {
+	delays := recordSleeps(t)
+	requests := 0
+	client, _ := newTestClient(t, failingHandler(1, http.StatusServiceUnavailable, nil, &requests))
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	response, err := client.GetWithContext(ctx, "/me")
+	if err != nil {
+		t.Fatalf("GetWithContext: %v", err)
+	}
+	if response.StatusCode != http.StatusServiceUnavailable || requests != 1 || len(*delays) != 0 {
+		t.Errorf("status %d after %d requests and %d waits, want 503 after 1 and no wait", response.StatusCode, requests, len(*delays))
+	}
+}
@@ -0,0 +1,100 @@
+package ovh
+
+import "strings"
+
+// Schema describes the routes and models of an API service, as published at
+// /{service}.json
+type Schema struct {
+	APIVersion   string `json:"apiVersion"`
+	BasePath     string `json:"basePath"`
+	ResourcePath string `json:"resourcePath"`
+	// Routes of the service
+	APIs []SchemaAPI `json:"apis"`
+	// Models of the service, by fully qualified name. e.g. "dedicated.Server"
+	Models map[string]SchemaModel `json:"models"`
+}
+
+// SchemaAPI describes a route of a service and its operations
+type SchemaAPI struct {
+	// Route, with {placeholders}. e.g. "/dedicated/server/{serviceName}"
+	Path        string            `json:"path"`
+	Description string            `json:"description"`
+	Operations  []SchemaOperation `json:"operations"`
+}
+
+// SchemaOperation describes a method of a route
+type SchemaOperation struct {
+	HTTPMethod       string `json:"httpMethod"`
+	Description      string `json:"description"`
+	NoAuthentication bool   `json:"noAuthentication"`
+	// Lifecycle of the operation, e.g. "PRODUCTION" or "DEPRECATED"
+	APIStatus struct {
+		Value       string `json:"value"`
+		Description string `json:"description"`
+	} `json:"apiStatus"`
+	Parameters   []SchemaParameter `json:"parameters"`
+	ResponseType string            `json:"responseType"`
+}
+
+// SchemaParameter describes a parameter of an operation
+type SchemaParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DataType    string `json:"dataType"`
+	FullType    string `json:"fullType"`
+	// Where the parameter goes: "path", "query" or "body"
+	ParamType string `json:"paramType"`
+	Required  bool   `json:"required"`
+}
+
+// SchemaModel describes a type of a service, either an object or an enum
+type SchemaModel struct {
+	ID          string `json:"id"`
+	Namespace   string `json:"namespace"`
+	Description string `json:"description"`
+	// Allowed values, for enums
+	Enum     []string `json:"enum,omitempty"`
+	EnumType string   `json:"enumType,omitempty"`
+	// Properties, for objects
+	Properties map[string]SchemaProperty `json:"properties,omitempty"`
+}
+
+// SchemaProperty describes a property of an object model
+type SchemaProperty struct {
+	Type        string `json:"type"`
+	FullType    string `json:"fullType"`
+	Description string `json:"description"`
+	CanBeNull   bool   `json:"canBeNull"`
+	ReadOnly    bool   `json:"readOnly"`
+}
+
+// Schema returns the schema of ``service``, e.g. "dedicated/server". Schemas
+// are fetched once per client, then served from memory.
+func (c *Client) Schema(service string) (*Schema, error) {
+	service = strings.Trim(service, "/")
+
+	c.lock.RLock()
+	schema := c.schemas[service]
+	c.lock.RUnlock()
+	if schema != nil {
+		return schema, nil
+	}
+
+	response, err := c.GetUnAuth("/" + service + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	schema = &Schema{}
+	if err := response.UnmarshalInto(schema); err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	if c.schemas == nil {
+		c.schemas = map[string]*Schema{}
+	}
+	c.schemas[service] = schema
+	c.lock.Unlock()
+	return schema, nil
+}
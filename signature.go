@@ -0,0 +1,114 @@
+package ovh
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// SignatureAlgorithm selects the hash used to sign authenticated requests.
+// Its value is the prefix of the X-Ovh-Signature header.
+type SignatureAlgorithm string
+
+// Available signature algorithms
+const (
+	SignatureSHA1   SignatureAlgorithm = "$1$"
+	SignatureSHA256 SignatureAlgorithm = "$2$"
+)
+
+// newHash returns a hash for the algorithm, SHA-1 by default
+func (a SignatureAlgorithm) newHash() (hash.Hash, error) {
+	switch a {
+	case "", SignatureSHA1:
+		return sha1.New(), nil
+	case SignatureSHA256:
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("ovh: unknown signature algorithm %q", string(a))
+}
+
+// sign computes the X-Ovh-Signature header value of a request
+func (c *Client) sign(consumerKey, method, target string, body []byte, timestamp int64) string {
+	// Reading from memory cannot fail
+	signature, _ := c.signStream(consumerKey, method, target, bytes.NewReader(body), timestamp)
+	return signature
+}
+
+// signStream is like sign, but hashes the body as it reads it from ``body``,
+// which is then rewound for the request to send it
+func (c *Client) signStream(consumerKey, method, target string, body io.ReadSeeker, timestamp int64) (string, error) {
+	algorithm := c.signatureAlgorithm
+	if algorithm == "" {
+		algorithm = SignatureSHA1
+	}
+
+	start, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+
+	// Algorithm was validated by WithSignatureAlgorithm
+	h, _ := algorithm.newHash()
+	fmt.Fprintf(h, "%s+%s+%s+%s+", c.applicationSecret, consumerKey, method, target)
+	if _, err := io.Copy(h, body); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "+%d", timestamp)
+
+	if _, err := body.Seek(start, io.SeekStart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%x", algorithm, h.Sum(nil)), nil
+}
+
+// Signature returns the X-Ovh-Signature header value of a request to the full
+// URL ``target`` with ``body``, signed with the client credentials at
+// ``timestamp``, as computed by Call
+func (c *Client) Signature(method, target string, body []byte, timestamp int64) string {
+	return c.sign(c.getConsumerKey(), method, target, body, timestamp)
+}
+
+// SignRequest returns the OVH authentication headers of a request to ``path``
+// with ``body``, without sending it, so that another component can send it.
+// The signature is bound to the current time: the request is rejected if sent
+// too late. With OAuth2, the headers carry the access token instead.
+func (c *Client) SignRequest(method, path string, body []byte) (http.Header, error) {
+	opts := &callOptions{}
+	if err := c.validateCredentials(opts); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	headers := http.Header{}
+	if c.oauth2 != nil {
+		token, err := c.oauth2.getToken(ctx, c.client)
+		if err != nil {
+			return nil, err
+		}
+		headers.Set("Authorization", "Bearer "+token)
+		return headers, nil
+	}
+
+	timeDelta, err := c.loadTimeDelta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timestamp := c.clock().Unix() - timeDelta
+	consumerKey := c.callConsumerKey(opts)
+	target, err := c.requestURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	headers.Set("X-Ovh-Application", c.applicationKey)
+	headers.Set("X-Ovh-Timestamp", strconv.FormatInt(timestamp, 10))
+	headers.Set("X-Ovh-Consumer", consumerKey)
+	headers.Set("X-Ovh-Signature", c.sign(consumerKey, method, target, body, timestamp))
+	return headers, nil
+}
@@ -0,0 +1,102 @@
+package ovh
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	const (
+		target    = "https://eu.api.ovh.com/1.0/me"
+		body      = `{"a":1}`
+		timestamp = 1457018875
+	)
+	toSign := "test-application-secret+test-consumer-key+POST+" + target + "+" + body + "+1457018875"
+
+	tests := []struct {
+		algorithm SignatureAlgorithm
+		want      string
+	}{
+		{"", fmt.Sprintf("$1$%x", sha1.Sum([]byte(toSign)))},
+		{SignatureSHA1, fmt.Sprintf("$1$%x", sha1.Sum([]byte(toSign)))},
+		{SignatureSHA256, fmt.Sprintf("$2$%x", sha256.Sum256([]byte(toSign)))},
+	}
+	for _, tt := range tests {
+		client, _ := newTestClient(t, respond(http.StatusOK, "{}"), WithSignatureAlgorithm(tt.algorithm))
+		got := client.Signature("POST", target, []byte(body), timestamp)
+		if got != tt.want {
+			t.Errorf("%q: Signature = %q, want %q", tt.algorithm, got, tt.want)
+		}
+	}
+}
+
+func TestSignatureUnknownAlgorithm(t *testing.T) {
+	_, err := NewClientWithOptions(WithBaseURL("https://example.com"), WithSignatureAlgorithm("$9$"))
+	if err == nil {
+		t.Fatal("expected an error for an unknown algorithm")
+	}
+}
+
+// The header sent by Call matches the signature of the request
+func TestCallSignatureHeader(t *testing.T) {
+	for _, algorithm := range []SignatureAlgorithm{SignatureSHA1, SignatureSHA256} {
+		var signature, ts, target string
+		client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get("X-Ovh-Signature")
+			ts = r.Header.Get("X-Ovh-Timestamp")
+			target = "http://" + r.Host + r.URL.RequestURI()
+			w.Write([]byte("{}"))
+		}, WithSignatureAlgorithm(algorithm))
+
+		if err := client.GetInto("/me", nil); err != nil {
+			t.Fatalf("GetInto: %v", err)
+		}
+		timestamp, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			t.Fatalf("X-Ovh-Timestamp %q: %v", ts, err)
+		}
+		if want := client.Signature("GET", target, nil, timestamp); signature != want {
+			t.Errorf("%s: X-Ovh-Signature = %q, want %q", algorithm, signature, want)
+		}
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	now := time.Unix(1457018875, 0)
+	client, recorded := newRecordingClient(t, WithClock(func() time.Time { return now }))
+
+	for _, path := range []string{"/domain/zone/a b/record", "/me/sshKey?keyName=laptop&x=1"} {
+		if _, err := client.Get(path); err != nil {
+			t.Fatalf("Get(%q): %v", path, err)
+		}
+		headers, err := client.SignRequest("GET", path, nil)
+		if err != nil {
+			t.Fatalf("SignRequest(%q): %v", path, err)
+		}
+		for _, name := range []string{"X-Ovh-Application", "X-Ovh-Consumer", "X-Ovh-Timestamp", "X-Ovh-Signature"} {
+			if got, want := headers.Get(name), recorded.Header.Get(name); got != want {
+				t.Errorf("SignRequest(%q): %s = %q, sent %q", path, name, got, want)
+			}
+		}
+	}
+}
+
+func TestSignRequestOAuth2(t *testing.T) {
+	isolateConfig(t)
+	ts := newTokenServer(t, 3600, 0)
+	client := newOAuth2Client(t, ts, respond(http.StatusOK, "{}"))
+
+	headers, err := client.SignRequest("GET", "/me", nil)
+	if err != nil {
+		t.Fatalf("SignRequest: %v", err)
+	}
+	want := http.Header{"Authorization": {"Bearer token-1"}}
+	if fmt.Sprint(headers) != fmt.Sprint(want) {
+		t.Errorf("SignRequest = %v, want %v", headers, want)
+	}
+}
@@ -0,0 +1,81 @@
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// CallReader is like CallWithOptions, but streams the request body from
+// ``body`` rather than buffering it in memory, for large uploads. ``body`` is
+// sent as is, without JSON marshalling, from its current offset. ``size`` is
+// its length from there, or -1 to let it be computed: a ``size`` not matching
+// the reader fails before anything is sent.
+//
+// Signing an authenticated request requires hashing its body first: ``body``
+// is read a first time to compute the signature, then rewound to where it
+// started and read again to be sent. It is rewound likewise before each retry.
+// The body is sent with the usual Content-Type, use the ContentType option to
+// set the actual one.
+func (c *Client) CallReader(ctx context.Context, method, path string, body io.ReadSeeker, size int64, needAuth bool, opts ...CallOption) (*APIResponse, error) {
+	options := &callOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := options.setStream(body, size); err != nil {
+		return nil, wrapCallError(method, path, err)
+	}
+	return c.callWithOptions(ctx, method, path, nil, needAuth, options)
+}
+
+// setStream makes ``body``, of length ``size``, the streamed request body
+func (o *callOptions) setStream(body io.ReadSeeker, size int64) error {
+	start, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	end, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := body.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	if size < 0 {
+		size = end - start
+	} else if size != end-start {
+		return fmt.Errorf("ovh: body size %d does not match the %d bytes left in the reader", size, end-start)
+	}
+
+	o.stream = body
+	o.streamStart = start
+	o.streamSize = size
+	return nil
+}
+
+// newStreamRequest builds the request of an attempt of CallReader, with the
+// body rewound to where it started
+func (c *Client) newStreamRequest(ctx context.Context, method, path string, opts *callOptions) (*http.Request, error) {
+	if _, err := opts.stream.Seek(opts.streamStart, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newBodyRequest(ctx, method, path, ioutil.NopCloser(opts.stream), opts)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = opts.streamSize
+	if opts.streamSize == 0 {
+		req.Body = nil
+	}
+	return req, nil
+}
+
+// PutReader Issues an authenticated put request on /path, streaming the body
+// from ``body`` of length ``size``. See CallReader.
+func (c *Client) PutReader(path string, body io.ReadSeeker, size int64, opts ...CallOption) (*APIResponse, error) {
+	return c.CallReader(context.Background(), "PUT", path, body, size, true, opts...)
+}
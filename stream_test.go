@@ -0,0 +1,92 @@
+package ovh
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const streamed = "\x00binary\xffpayload"
+
+// The streamed bytes are signed and sent with the requested Content-Type
+func TestPutReader(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	response, err := client.PutReader("/me/document/1", strings.NewReader(streamed), int64(len(streamed)), ContentType("application/octet-stream"))
+	if err != nil {
+		t.Fatalf("PutReader: %v", err)
+	}
+	if response.Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", response.Attempts)
+	}
+	if string(recorded.Body) != streamed {
+		t.Errorf("body = %q, want %q", recorded.Body, streamed)
+	}
+	if got := recorded.Header.Get("Content-Type"); got != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, want application/octet-stream", got)
+	}
+	checkSignature(t, client, recorded)
+}
+
+// The body is sent from the current offset of the reader, -1 standing for
+// its remaining length
+func TestPutReaderOffset(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	body := strings.NewReader("skipped:" + streamed)
+	body.Seek(int64(len("skipped:")), io.SeekStart)
+	if _, err := client.PutReader("/me/document/1", body, -1); err != nil {
+		t.Fatalf("PutReader: %v", err)
+	}
+	if string(recorded.Body) != streamed {
+		t.Errorf("body = %q, want %q", recorded.Body, streamed)
+	}
+	if recorded.Header.Get("Content-Length") == "" {
+		t.Error("no Content-Length for a body of known length")
+	}
+	checkSignature(t, client, recorded)
+}
+
+func TestPutReaderSizeMismatch(t *testing.T) {
+	client, recorded := newRecordingClient(t)
+
+	for _, size := range []int64{0, int64(len(streamed)) - 1, int64(len(streamed)) + 1} {
+		_, err := client.PutReader("/me/document/1", strings.NewReader(streamed), size)
+		if err == nil || !strings.Contains(err.Error(), "does not match") {
+			t.Errorf("size %d: PutReader error = %v, want a size mismatch", size, err)
+		}
+	}
+	if recorded.Method != "" {
+		t.Error("request sent despite the size mismatch")
+	}
+}
+
+// Retries rewind the body, so that each attempt sends and signs all of it
+func TestPutReaderRetry(t *testing.T) {
+	recordSleeps(t)
+
+	var bodies []string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	client.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	response, err := client.PutReader("/me/document/1", strings.NewReader(streamed), int64(len(streamed)))
+	if err != nil {
+		t.Fatalf("PutReader: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || response.Attempts != 2 {
+		t.Errorf("status %d after %d attempts, want 200 after 2", response.StatusCode, response.Attempts)
+	}
+	if len(bodies) != 2 || bodies[0] != streamed || bodies[1] != streamed {
+		t.Errorf("bodies sent = %q, want %q twice", bodies, streamed)
+	}
+}
@@ -0,0 +1,128 @@
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TaskPollInterval is the delay between two checks of a task status in
+// WaitForTask
+var TaskPollInterval = 5 * time.Second
+
+// ErrTaskTimeout is returned by WaitForTask when the task is still running
+// once the timeout elapsed
+var ErrTaskTimeout = errors.New("ovh: timed out waiting for task")
+
+// Task represents an asynchronous operation, as returned by write operations
+// on dedicated servers or cloud projects
+type Task struct {
+	// Task id. Depending on the API, it is exposed as "id" or "taskId"
+	ID int64 `json:"id"`
+	// Operation run by the task, when provided
+	Function string `json:"function,omitempty"`
+	// Current status. e.g. "todo", "doing", "done"
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"`
+
+	StartDate  *time.Time `json:"startDate,omitempty"`
+	DoneDate   *time.Time `json:"doneDate,omitempty"`
+	LastUpdate *time.Time `json:"lastUpdate,omitempty"`
+}
+
+// Task statuses meaning the task will not complete
+var taskFailedStatuses = map[string]bool{
+	"error":         true,
+	"cancelled":     true,
+	"customerError": true,
+	"ovhError":      true,
+}
+
+// UnmarshalJSON implements json.Unmarshaler, mapping "taskId" to ID
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type task Task
+	aux := struct {
+		*task
+		TaskID *int64 `json:"taskId"`
+	}{task: (*task)(t)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.TaskID != nil {
+		t.ID = *aux.TaskID
+	}
+	return nil
+}
+
+// Done reports whether the task completed successfully
+func (t *Task) Done() bool {
+	return t.Status == "done"
+}
+
+// Failed reports whether the task ended without completing
+func (t *Task) Failed() bool {
+	return taskFailedStatuses[t.Status]
+}
+
+// TaskError reports a task which ended without completing
+type TaskError struct {
+	Path string
+	Task *Task
+}
+
+// Error implements the error interface
+func (e *TaskError) Error() string {
+	if e.Task.Comment == "" {
+		return fmt.Sprintf("ovh: task %s ended with status %q", e.Path, e.Task.Status)
+	}
+	return fmt.Sprintf("ovh: task %s ended with status %q: %s", e.Path, e.Task.Status, e.Task.Comment)
+}
+
+// WaitForTask polls the task at ``path`` every TaskPollInterval until it
+// completes. A *TaskError is returned if it fails, and an error wrapping
+// ErrTaskTimeout if it is still running after ``timeout``.
+func (c *Client) WaitForTask(path string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := c.WaitForTaskWithContext(ctx, path)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s still running after %s", ErrTaskTimeout, path, timeout)
+	}
+	return err
+}
+
+// WaitForTaskWithContext is like WaitForTask, but polls until ``ctx`` is done.
+// The task is returned in its final state.
+func (c *Client) WaitForTaskWithContext(ctx context.Context, path string) (*Task, error) {
+	ticker := time.NewTicker(TaskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		response, err := c.GetWithContext(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		task := &Task{}
+		if err := response.UnmarshalInto(task); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case task.Done():
+			return task, nil
+		case task.Failed():
+			return task, &TaskError{Path: path, Task: task}
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForTask(t *testing.T) {
+	fastPolling(t)
+
+	polls := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			w.Write([]byte(`{"taskId":42,"status":"doing"}`))
+			return
+		}
+		w.Write([]byte(`{"taskId":42,"status":"done"}`))
+	})
+
+	if err := client.WaitForTask("/me/task/42", 5*time.Second); err != nil {
+		t.Fatalf("WaitForTask: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("%d polls, want 3", polls)
+	}
+}
+
+func TestWaitForTaskFailed(t *testing.T) {
+	fastPolling(t)
+
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"id":42,"status":"error","comment":"disk full"}`))
+
+	err := client.WaitForTask("/me/task/42", 5*time.Second)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Task.ID != 42 {
+		t.Fatalf("WaitForTask error = %v, want a *TaskError", err)
+	}
+	if want := `ovh: task /me/task/42 ended with status "error": disk full`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestWaitForTaskTimeout(t *testing.T) {
+	fastPolling(t)
+
+	client, _ := newTestClient(t, respond(http.StatusOK, `{"id":42,"status":"doing"}`))
+
+	err := client.WaitForTask("/me/task/42", 20*time.Millisecond)
+	if !errors.Is(err, ErrTaskTimeout) {
+		t.Fatalf("WaitForTask error = %v, want ErrTaskTimeout", err)
+	}
+	if !strings.HasPrefix(err.Error(), "ovh: ") || !strings.Contains(err.Error(), "/me/task/42") {
+		t.Errorf("error = %q, want an ovh: error naming the task", err)
+	}
+}
@@ -0,0 +1,105 @@
+package ovh
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newTransport returns the default transport of clients. Like
+// http.DefaultTransport, it honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables.
+func newTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+// WithProxy sends requests through the proxy at ``proxyURL`` rather than the
+// one configured in the environment
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("ovh: invalid proxy URL %q: %v", proxyURL, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("ovh: invalid proxy URL %q: not an absolute URL", proxyURL)
+		}
+		c.proxyURL = u
+		return nil
+	}
+}
+
+// WithDialTimeout bounds the time spent establishing the TCP connection, so
+// that an unreachable endpoint is detected early rather than after the whole
+// request timeout. The default is the one of http.DefaultTransport.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.dialTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSHandshakeTimeout bounds the time spent in the TLS handshake. The
+// default is the one of http.DefaultTransport.
+func WithTLSHandshakeTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.tlsHandshakeTimeout = timeout
+		return nil
+	}
+}
+
+// configureTransport applies the transport level options. The http.Client and
+// transport given with WithHTTPClient are copied rather than modified.
+func (c *Client) configureTransport() error {
+	if c.proxyURL == nil && c.dialTimeout == 0 && c.tlsHandshakeTimeout == 0 {
+		return nil
+	}
+
+	var transport *http.Transport
+	switch t := c.client.Transport.(type) {
+	case nil:
+		transport = newTransport()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return errors.New("ovh: transport options require the http client to use an *http.Transport")
+	}
+
+	if c.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(c.proxyURL)
+	}
+	if c.dialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   c.dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if c.tlsHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = c.tlsHandshakeTimeout
+	}
+
+	httpClient := *c.client
+	httpClient.Transport = transport
+	c.client = &httpClient
+	c.transport = transport
+	return nil
+}
+
+// Close releases the idle keep-alive connections of the client. The client
+// starts no background goroutine, so nothing else lingers once it is closed.
+// It remains usable afterwards, new connections being opened as needed.
+//
+// A transport given with WithHTTPClient may be shared with other clients,
+// it is left untouched: closing its connections is up to its owner.
+func (c *Client) Close() error {
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package ovh
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeProxy starts a proxy answering ``{}`` to every request, and returns
+// it along with the absolute URLs it was asked for
+func newFakeProxy(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var lock sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		proxied = append(proxied, r.URL.String())
+		lock.Unlock()
+		w.Write([]byte("{}"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	return proxy, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), proxied...)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy, proxied := newFakeProxy(t)
+
+	client, err := NewClientWithOptions(
+		WithBaseURL("http://api.ovh.invalid/1.0"),
+		WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+		WithTimeDelta(0),
+		WithProxy(proxy.URL),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if err := client.GetInto("/me", nil); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+
+	if got := proxied(); len(got) != 1 || got[0] != "http://api.ovh.invalid/1.0/me" {
+		t.Errorf("proxied requests = %q", got)
+	}
+}
+
+func TestWithProxyInvalid(t *testing.T) {
+	for _, proxyURL := range []string{"", "proxy:3128", "://proxy"} {
+		if _, err := NewClientWithOptions(WithBaseURL("http://api.ovh.invalid/1.0"), WithProxy(proxyURL)); err == nil {
+			t.Errorf("WithProxy(%q) accepted an invalid URL", proxyURL)
+		}
+	}
+}
+
+// The proxy environment is read once per process: the test runs itself again
+// with HTTP_PROXY pointing at the fake proxy
+func TestEnvironmentProxy(t *testing.T) {
+	if os.Getenv("GO_OVH_TEST_ENV_PROXY") != "" {
+		client, err := NewClientWithOptions(
+			WithBaseURL("http://api.ovh.invalid/1.0"),
+			WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+			WithTimeDelta(0),
+		)
+		if err != nil {
+			t.Fatalf("NewClientWithOptions: %v", err)
+		}
+		if err := client.GetInto("/me", nil); err != nil {
+			t.Fatalf("GetInto: %v", err)
+		}
+		return
+	}
+
+	proxy, proxied := newFakeProxy(t)
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestEnvironmentProxy$")
+	cmd.Env = append(os.Environ(), "GO_OVH_TEST_ENV_PROXY=1", "HTTP_PROXY="+proxy.URL, "http_proxy="+proxy.URL, "NO_PROXY=", "no_proxy=")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, output)
+	}
+
+	if got := proxied(); len(got) != 1 || got[0] != "http://api.ovh.invalid/1.0/me" {
+		t.Errorf("proxied requests = %q", got)
+	}
+}
+
+// closeCounter is a transport counting the CloseIdleConnections calls
+type closeCounter struct {
+	http.RoundTripper
+	closes int
+}
+
+func (c *closeCounter) CloseIdleConnections() {
+	c.closes++
+}
+
+func TestClose(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			select {
+			case closed <- struct{}{}:
+			default:
+			}
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+		WithTimeDelta(0),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if err := client.GetInto("/me", nil); err != nil {
+		t.Fatalf("GetInto: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("the idle connection was not closed")
+	}
+}
+
+// Transports given with WithHTTPClient may be shared, and are left open
+func TestCloseSharedTransport(t *testing.T) {
+	shared := &closeCounter{RoundTripper: http.DefaultTransport}
+	client, err := NewClientWithOptions(
+		WithBaseURL("http://api.ovh.invalid/1.0"),
+		WithCredentials(testApplicationKey, testApplicationSecret, testConsumerKey),
+		WithHTTPClient(&http.Client{Transport: shared}),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if shared.closes != 0 {
+		t.Errorf("Close closed the connections of a shared transport")
+	}
+}
@@ -0,0 +1,28 @@
+//go:build go1.18
+// +build go1.18
+
+package ovh
+
+// GetTyped issues an authenticated get request on /path and decodes the
+// response into a T
+func GetTyped[T any](c *Client, path string) (T, error) {
+	var out T
+	err := c.GetInto(path, &out)
+	return out, err
+}
+
+// PostTyped issues an authenticated post request on /path and decodes the
+// created resource into a T
+func PostTyped[T any](c *Client, path string, data interface{}) (T, error) {
+	var out T
+	err := c.PostInto(path, data, &out)
+	return out, err
+}
+
+// PutTyped issues an authenticated put request on /path and decodes the
+// updated resource into a T
+func PutTyped[T any](c *Client, path string, data interface{}) (T, error) {
+	var out T
+	err := c.PutInto(path, data, &out)
+	return out, err
+}